@@ -108,14 +108,37 @@ func TestSzTest(t *testing.T) {
 	t.Run("chkInterface", chkInterface)
 
 	t.Run("chkLogging", tstChkLogging)
+	t.Run("chkLogMatch", tstChkLogMatch)
+	t.Run("chkLogWait", tstChkLogWait)
 	t.Run("chkSubstitution", tstChkSubstitution)
 
 	t.Run("chkDir", tstChkDir)
+	t.Run("chkDirTxtar", tstChkDirTxtar)
+	t.Run("chkDirTree", tstChkDirTree)
+	t.Run("chkDirSnapshot", tstChkDirSnapshot)
+	t.Run("chkDirSpecial", tstChkDirSpecial)
+	t.Run("chkDirIsolate", tstChkDirIsolate)
+	t.Run("chkDirKeep", tstChkDirKeep)
+	t.Run("chkChdir", tstChkChdir)
+	t.Run("chkFakeCommand", tstChkFakeCommand)
+	t.Run("chkExec", tstChkExec)
+	t.Run("chkFile", tstChkFile)
 	t.Run("chkEnv", tstChkEnv)
 	t.Run("chkIoClose", tstChkIoClose)
 	t.Run("chkIoReader", tstChkIoReader)
 	t.Run("chkIoWriter", tstChkIoWriter)
 	t.Run("chkIoSeek", tstChkIoSeek)
+	t.Run("chkIoFake", tstChkIoFake)
+	t.Run("chkIoSchedule", tstChkIoSchedule)
+	t.Run("chkIoConn", tstChkIoConn)
+	t.Run("chkIoPace", tstChkIoPace)
+	t.Run("chkIoCalls", tstChkIoCalls)
+	t.Run("chkIoStrict", tstChkIoStrict)
+	t.Run("chkIoStdin", tstChkIoStdin)
+	t.Run("chkIoInteract", tstChkIoInteract)
+
+	t.Run("txtar", tstTxtar)
+	t.Run("chkFsFake", tstChkFsFake)
 
 	t.Run("chkArgsAndFlags", tstChkArgsAndFlags)
 
@@ -125,6 +148,7 @@ func TestSzTest(t *testing.T) {
 func chkData(t *testing.T) {
 	t.Run("Bool", tstChkBool)
 	t.Run("Byte", tstChkByte)
+	t.Run("Bytes", tstChkBytes)
 	t.Run("Complex64", tstChkComplex64)
 	t.Run("Complex128", tstChkComplex128)
 	t.Run("Float32", tstChkFloat32)
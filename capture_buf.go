@@ -0,0 +1,127 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bytes"
+	"sync"
+)
+
+// captureBuf holds the output collected from a redirected stream.  Writes
+// arrive from the copier goroutines (or directly from the log package) while
+// the checks read from the test goroutine so all access is serialized.
+//
+// Once the buffer holds limit bytes (when limit is greater than zero) any
+// further output is counted but discarded so runaway output cannot exhaust
+// memory.
+type captureBuf struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	notify    chan struct{}
+	limit     int
+	discarded int
+}
+
+func newCaptureBuf() *captureBuf {
+	capBuf := new(captureBuf)
+	capBuf.limit = settingCaptureMax
+	capBuf.buf.Grow(settingBufferSize)
+
+	return capBuf
+}
+
+// Write implements io.Writer.  The full length of data is always reported
+// as written even if some or all of it was discarded.
+func (capBuf *captureBuf) Write(data []byte) (int, error) {
+	capBuf.mu.Lock()
+	defer capBuf.mu.Unlock()
+
+	if capBuf.notify != nil {
+		close(capBuf.notify)
+		capBuf.notify = nil
+	}
+
+	keep := data
+
+	if capBuf.limit > 0 {
+		room := max(capBuf.limit-capBuf.buf.Len(), 0)
+		if len(keep) > room {
+			capBuf.discarded += len(keep) - room
+			keep = keep[:room]
+		}
+	}
+
+	_, _ = capBuf.buf.Write(keep)
+
+	return len(data), nil
+}
+
+// String returns the data not yet consumed without removing it.
+func (capBuf *captureBuf) String() string {
+	capBuf.mu.Lock()
+	defer capBuf.mu.Unlock()
+
+	return capBuf.buf.String()
+}
+
+// watch returns the data not yet consumed along with a channel that is
+// closed by the next write to the buffer.
+func (capBuf *captureBuf) watch() (string, <-chan struct{}) {
+	capBuf.mu.Lock()
+	defer capBuf.mu.Unlock()
+
+	if capBuf.notify == nil {
+		capBuf.notify = make(chan struct{})
+	}
+
+	return capBuf.buf.String(), capBuf.notify
+}
+
+// snapshot returns the data not yet consumed and the number of bytes
+// discarded since the last consume without removing anything.
+func (capBuf *captureBuf) snapshot() (string, int) {
+	capBuf.mu.Lock()
+	defer capBuf.mu.Unlock()
+
+	return capBuf.buf.String(), capBuf.discarded
+}
+
+// consume returns the data not yet consumed and the number of bytes
+// discarded while it was collected, then drains the buffer.
+func (capBuf *captureBuf) consume() (string, int) {
+	capBuf.mu.Lock()
+	defer capBuf.mu.Unlock()
+
+	data := capBuf.buf.String()
+	discarded := capBuf.discarded
+
+	capBuf.buf.Reset()
+	capBuf.discarded = 0
+
+	return data, discarded
+}
+
+// reset discards all data not yet consumed.
+func (capBuf *captureBuf) reset() {
+	capBuf.mu.Lock()
+	defer capBuf.mu.Unlock()
+
+	capBuf.buf.Reset()
+	capBuf.discarded = 0
+}
@@ -20,33 +20,39 @@ package sztest
 
 import (
 	"os"
+	"time"
 )
 
 //nolint:goCheckNoGlobals // Ok - initialized by init function.
 var (
-	defTmpDir         = os.TempDir()
-	settingFailFast   bool
-	settingBufferSize int
-	settingPermDir    os.FileMode
-	settingPermFile   os.FileMode
-	settingPermExe    os.FileMode
-	settingTmpDir     string
-	settingDiffChars  int
-	settingDiffSlice  int
-	settingMarkWntOn  string
-	settingMarkWntOff string
-	settingMarkGotOn  string
-	settingMarkGotOff string
-	settingMarkMsgOn  string
-	settingMarkMsgOff string
-	settingMarkInsOn  string
-	settingMarkInsOff string
-	settingMarkDelOn  string
-	settingMarkDelOff string
-	settingMarkChgOn  string
-	settingMarkChgOff string
-	settingMarkSepOn  string
-	settingMarkSepOff string
+	defTmpDir          = os.TempDir()
+	settingFailFast    bool
+	settingBufferSize  int
+	settingCaptureMax  int
+	settingExecTimeout time.Duration
+	settingPermDir     os.FileMode
+	settingPermFile    os.FileMode
+	settingPermExe     os.FileMode
+	settingTmpDir      string
+	settingTmpIsolate  bool
+	settingTmpPrune    time.Duration
+	settingKeepTmp     string
+	settingDiffChars   int
+	settingDiffSlice   int
+	settingMarkWntOn   string
+	settingMarkWntOff  string
+	settingMarkGotOn   string
+	settingMarkGotOff  string
+	settingMarkMsgOn   string
+	settingMarkMsgOff  string
+	settingMarkInsOn   string
+	settingMarkInsOff  string
+	settingMarkDelOn   string
+	settingMarkDelOff  string
+	settingMarkChgOn   string
+	settingMarkChgOff  string
+	settingMarkSepOn   string
+	settingMarkSepOff  string
 )
 
 // ReloadSettings re-initializes the settings maintaining global configuration
@@ -74,6 +80,17 @@ func SettingBufferSize() int {
 	return settingBufferSize
 }
 
+// SettingCaptureMax returns the default setting overridden by env settings.
+func SettingCaptureMax() int {
+	return settingCaptureMax
+}
+
+// SettingExecTimeout returns the longest a command run by Chk.Exec may take
+// before it is killed and the test failed.
+func SettingExecTimeout() time.Duration {
+	return settingExecTimeout
+}
+
 // SettingPermDir returns the default setting overridden by env settings.
 func SettingPermDir() os.FileMode {
 	return settingPermDir
@@ -94,6 +111,24 @@ func SettingTmpDir() string {
 	return settingTmpDir
 }
 
+// SettingTmpIsolate returns the default setting overridden by env settings.
+func SettingTmpIsolate() bool {
+	return settingTmpIsolate
+}
+
+// SettingTmpPrune returns the age after which isolated temporary
+// directories left behind by earlier runs are removed. Zero disables
+// pruning.
+func SettingTmpPrune() time.Duration {
+	return settingTmpPrune
+}
+
+// SettingKeepTmp returns when temporary files are retained after a test:
+// "never", "on-failure" or "always".
+func SettingKeepTmp() string {
+	return settingKeepTmp
+}
+
 // SettingDiffChars returns the minimum number of consecutive matching
 // characters required within a line for sztest to treat regions of
 // `got` and `wnt` strings as identical when computing diffs. In effect,
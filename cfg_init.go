@@ -20,56 +20,67 @@ package sztest
 
 import (
 	"os"
+	"time"
 )
 
 // Environment variable identifiers.
 const (
-	EnvFailFast   = "SZTEST_FAIL_FAST"
-	EnvBufferSize = "SZTEST_BUFFER_SIZE"
-	EnvPermDir    = "SZTEST_PERM_DIR"
-	EnvPermFile   = "SZTEST_PERM_FILE"
-	EnvPermExe    = "SZTEST_PERM_EXE"
-	EnvTmpDir     = "SZTEST_TMP_DIR"
-	EnvDiffChars  = "SZTEST_DIFF_CHARS"
-	EnvDiffSlice  = "SZTEST_DIFF_SLICE"
-	EnvMarkWntOn  = "SZTEST_MARK_WNT_ON"
-	EnvMarkWntOff = "SZTEST_MARK_WNT_OFF"
-	EnvMarkGotOn  = "SZTEST_MARK_GOT_ON"
-	EnvMarkGotOff = "SZTEST_MARK_GOT_OFF"
-	EnvMarkMsgOn  = "SZTEST_MARK_MSG_ON"
-	EnvMarkMsgOff = "SZTEST_MARK_MSG_OFF"
-	EnvMarkInsOn  = "SZTEST_MARK_INS_ON"
-	EnvMarkInsOff = "SZTEST_MARK_INS_OFF"
-	EnvMarkDelOn  = "SZTEST_MARK_DEL_ON"
-	EnvMarkDelOff = "SZTEST_MARK_DEL_OFF"
-	EnvMarkChgOn  = "SZTEST_MARK_CHG_ON"
-	EnvMarkChgOff = "SZTEST_MARK_CHG_OFF"
-	EnvMarkSepOn  = "SZTEST_MARK_SEP_ON"
-	EnvMarkSepOff = "SZTEST_MARK_SEP_OFF"
+	EnvFailFast    = "SZTEST_FAIL_FAST"
+	EnvBufferSize  = "SZTEST_BUFFER_SIZE"
+	EnvCaptureMax  = "SZTEST_CAPTURE_MAX"
+	EnvExecTimeout = "SZTEST_EXEC_TIMEOUT"
+	EnvPermDir     = "SZTEST_PERM_DIR"
+	EnvPermFile    = "SZTEST_PERM_FILE"
+	EnvPermExe     = "SZTEST_PERM_EXE"
+	EnvTmpDir      = "SZTEST_TMP_DIR"
+	EnvTmpIsolate  = "SZTEST_TMP_ISOLATE"
+	EnvTmpPrune    = "SZTEST_TMP_PRUNE"
+	EnvKeepTmp     = "SZTEST_KEEP_TMP"
+	EnvDiffChars   = "SZTEST_DIFF_CHARS"
+	EnvDiffSlice   = "SZTEST_DIFF_SLICE"
+	EnvMarkWntOn   = "SZTEST_MARK_WNT_ON"
+	EnvMarkWntOff  = "SZTEST_MARK_WNT_OFF"
+	EnvMarkGotOn   = "SZTEST_MARK_GOT_ON"
+	EnvMarkGotOff  = "SZTEST_MARK_GOT_OFF"
+	EnvMarkMsgOn   = "SZTEST_MARK_MSG_ON"
+	EnvMarkMsgOff  = "SZTEST_MARK_MSG_OFF"
+	EnvMarkInsOn   = "SZTEST_MARK_INS_ON"
+	EnvMarkInsOff  = "SZTEST_MARK_INS_OFF"
+	EnvMarkDelOn   = "SZTEST_MARK_DEL_ON"
+	EnvMarkDelOff  = "SZTEST_MARK_DEL_OFF"
+	EnvMarkChgOn   = "SZTEST_MARK_CHG_ON"
+	EnvMarkChgOff  = "SZTEST_MARK_CHG_OFF"
+	EnvMarkSepOn   = "SZTEST_MARK_SEP_ON"
+	EnvMarkSepOff  = "SZTEST_MARK_SEP_OFF"
 )
 
 const (
-	defFailFast   = true
-	defBufferSize = 10_000
-	defPermDir    = os.FileMode(0o0700)
-	defPermFile   = os.FileMode(0o0600)
-	defPermExe    = os.FileMode(0o0700)
-	defDiffChars  = 3
-	defDiffSlice  = 1
-	defMarkWntOn  = clrCyan
-	defMarkWntOff = clrOff
-	defMarkGotOn  = clrMagenta
-	defMarkGotOff = clrOff
-	defMarkMsgOn  = clrBold + clrItalic + clrUnderline
-	defMarkMsgOff = clrOff
-	defMarkInsOn  = clrGreen + clrReverse
-	defMarkInsOff = clrOff
-	defMarkDelOn  = clrRed + clrReverse
-	defMarkDelOff = clrOff
-	defMarkChgOn  = clrBlue + clrReverse
-	defMarkChgOff = clrOff
-	defMarkSepOn  = clrBkYellow
-	defMarkSepOff = clrOff
+	defFailFast    = true
+	defBufferSize  = 10_000
+	defCaptureMax  = 0
+	defExecTimeout = 10 * time.Second
+	defPermDir     = os.FileMode(0o0700)
+	defPermFile    = os.FileMode(0o0600)
+	defPermExe     = os.FileMode(0o0700)
+	defTmpIsolate  = false
+	defTmpPrune    = time.Duration(0)
+	defKeepTmp     = keepTmpOnFailure
+	defDiffChars   = 3
+	defDiffSlice   = 1
+	defMarkWntOn   = clrCyan
+	defMarkWntOff  = clrOff
+	defMarkGotOn   = clrMagenta
+	defMarkGotOff  = clrOff
+	defMarkMsgOn   = clrBold + clrItalic + clrUnderline
+	defMarkMsgOff  = clrOff
+	defMarkInsOn   = clrGreen + clrReverse
+	defMarkInsOff  = clrOff
+	defMarkDelOn   = clrRed + clrReverse
+	defMarkDelOff  = clrOff
+	defMarkChgOn   = clrBlue + clrReverse
+	defMarkChgOff  = clrOff
+	defMarkSepOn   = clrBkYellow
+	defMarkSepOff  = clrOff
 )
 
 //nolint:goCheckNoInits // Ok.
@@ -80,10 +91,15 @@ func init() {
 func initAll() {
 	initFailFast()
 	initBufferSize()
+	initCaptureMax()
+	initExecTimeout()
 	initPermDir()
 	initPermFile()
 	initPermExe()
 	initTmpDir()
+	initTmpIsolate()
+	initTmpPrune()
+	initKeepTmp()
 	initDiffChars()
 	initDiffSlice()
 
@@ -131,6 +147,34 @@ func initBufferSize() {
 	settingBufferSize = result
 }
 
+func initCaptureMax() {
+	result := defCaptureMax
+	v, ok := os.LookupEnv(EnvCaptureMax)
+
+	if ok {
+		cleanValue, passed := validateCaptureMax(v)
+		if passed {
+			result = cleanValue
+		}
+	}
+
+	settingCaptureMax = result
+}
+
+func initExecTimeout() {
+	result := defExecTimeout
+	v, ok := os.LookupEnv(EnvExecTimeout)
+
+	if ok {
+		cleanValue, passed := validateExecTimeout(v)
+		if passed {
+			result = cleanValue
+		}
+	}
+
+	settingExecTimeout = result
+}
+
 func initPermDir() {
 	result := defPermDir
 	v, ok := os.LookupEnv(EnvPermDir)
@@ -187,6 +231,48 @@ func initTmpDir() {
 	settingTmpDir = os.TempDir()
 }
 
+func initTmpIsolate() {
+	result := defTmpIsolate
+	v, ok := os.LookupEnv(EnvTmpIsolate)
+
+	if ok {
+		cleanValue, passed := validateTmpIsolate(v)
+		if passed {
+			result = cleanValue
+		}
+	}
+
+	settingTmpIsolate = result
+}
+
+func initTmpPrune() {
+	result := defTmpPrune
+	v, ok := os.LookupEnv(EnvTmpPrune)
+
+	if ok {
+		cleanValue, passed := validateTmpPrune(v)
+		if passed {
+			result = cleanValue
+		}
+	}
+
+	settingTmpPrune = result
+}
+
+func initKeepTmp() {
+	result := defKeepTmp
+	v, ok := os.LookupEnv(EnvKeepTmp)
+
+	if ok {
+		cleanValue, passed := validateKeepTmp(v)
+		if passed {
+			result = cleanValue
+		}
+	}
+
+	settingKeepTmp = result
+}
+
 func initDiffChars() {
 	result := defDiffChars
 	v, ok := os.LookupEnv(EnvDiffChars)
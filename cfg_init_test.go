@@ -23,6 +23,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 )
 
 func testConfigInit(t *testing.T) {
@@ -62,6 +63,9 @@ func captureAll() []*sysEnvVar {
 		capture(EnvPermFile),
 		capture(EnvPermExe),
 		capture(EnvTmpDir),
+		capture(EnvTmpIsolate),
+		capture(EnvTmpPrune),
+		capture(EnvKeepTmp),
 		capture(EnvMarkWntOn),
 		capture(EnvMarkWntOff),
 		capture(EnvMarkGotOn),
@@ -79,6 +83,8 @@ func captureAll() []*sysEnvVar {
 		capture(EnvDiffChars),
 		capture(EnvDiffSlice),
 		capture(EnvBufferSize),
+		capture(EnvCaptureMax),
+		capture(EnvExecTimeout),
 	}
 }
 
@@ -133,6 +139,14 @@ func setupSysEnvVarTest(tmpDir string) error {
 		return fmt.Errorf(errMsg, EnvBufferSize, err)
 	}
 
+	if err = os.Setenv(EnvCaptureMax, "23456"); err != nil {
+		return fmt.Errorf(errMsg, EnvCaptureMax, err)
+	}
+
+	if err = os.Setenv(EnvExecTimeout, "2m"); err != nil {
+		return fmt.Errorf(errMsg, EnvExecTimeout, err)
+	}
+
 	if err = os.Setenv(EnvPermDir, "0701"); err != nil {
 		return fmt.Errorf(errMsg, EnvPermDir, err)
 	}
@@ -149,6 +163,18 @@ func setupSysEnvVarTest(tmpDir string) error {
 		return fmt.Errorf(errMsg, EnvTmpDir, err)
 	}
 
+	if err = os.Setenv(EnvTmpIsolate, "true"); err != nil {
+		return fmt.Errorf(errMsg, EnvTmpIsolate, err)
+	}
+
+	if err = os.Setenv(EnvTmpPrune, "36h"); err != nil {
+		return fmt.Errorf(errMsg, EnvTmpPrune, err)
+	}
+
+	if err = os.Setenv(EnvKeepTmp, "Always"); err != nil {
+		return fmt.Errorf(errMsg, EnvKeepTmp, err)
+	}
+
 	if err = os.Setenv(EnvDiffChars, "2"); err != nil {
 		return fmt.Errorf(errMsg, EnvDiffChars, err)
 	}
@@ -234,6 +260,16 @@ func testCfgInitAllDefaults(t *testing.T) {
 		t.Fatalf(errMsg, EnvBufferSize, settingBufferSize, defBufferSize)
 	}
 
+	if settingCaptureMax != defCaptureMax ||
+		SettingCaptureMax() != defCaptureMax {
+		t.Fatalf(errMsg, EnvCaptureMax, settingCaptureMax, defCaptureMax)
+	}
+
+	if settingExecTimeout != defExecTimeout ||
+		SettingExecTimeout() != defExecTimeout {
+		t.Fatalf(errMsg, EnvExecTimeout, settingExecTimeout, defExecTimeout)
+	}
+
 	if settingPermDir != defPermDir ||
 		SettingPermDir() != defPermDir {
 		t.Fatalf(errMsg, EnvPermDir, settingPermDir, defPermDir)
@@ -254,6 +290,20 @@ func testCfgInitAllDefaults(t *testing.T) {
 		t.Fatalf(errMsg, EnvTmpDir, settingTmpDir, defTmpDir)
 	}
 
+	if settingTmpIsolate || SettingTmpIsolate() {
+		t.Fatalf(errMsg, EnvTmpIsolate, settingTmpIsolate, defTmpIsolate)
+	}
+
+	if settingTmpPrune != defTmpPrune ||
+		SettingTmpPrune() != defTmpPrune {
+		t.Fatalf(errMsg, EnvTmpPrune, settingTmpPrune, defTmpPrune)
+	}
+
+	if settingKeepTmp != defKeepTmp ||
+		SettingKeepTmp() != defKeepTmp {
+		t.Fatalf(errMsg, EnvKeepTmp, settingKeepTmp, defKeepTmp)
+	}
+
 	if settingDiffChars != defDiffChars ||
 		SettingDiffChars() != defDiffChars {
 		t.Fatalf(errMsg, EnvDiffChars, settingDiffChars, defDiffChars)
@@ -380,6 +430,20 @@ func testCfgInitAllOverrides(t *testing.T) {
 		t.Fatalf(errMsg, EnvTmpDir, settingTmpDir, userHomeDir)
 	}
 
+	if !settingTmpIsolate || !SettingTmpIsolate() {
+		t.Fatalf(errMsg, EnvTmpIsolate, settingTmpIsolate, true)
+	}
+
+	if settingTmpPrune != 36*time.Hour ||
+		SettingTmpPrune() != 36*time.Hour {
+		t.Fatalf(errMsg, EnvTmpPrune, settingTmpPrune, 36*time.Hour)
+	}
+
+	if settingKeepTmp != keepTmpAlways ||
+		SettingKeepTmp() != keepTmpAlways {
+		t.Fatalf(errMsg, EnvKeepTmp, settingKeepTmp, keepTmpAlways)
+	}
+
 	if settingMarkWntOn != "<<WntOn>>" ||
 		SettingMarkWntOn() != "<<WntOn>>" {
 		t.Fatalf(errMsg, EnvMarkWntOn, settingMarkWntOn, "<<WntOn>>")
@@ -464,4 +528,14 @@ func testCfgInitAllOverrides(t *testing.T) {
 		SettingBufferSize() != 12345 {
 		t.Fatalf(errMsg, EnvBufferSize, settingBufferSize, 12345)
 	}
+
+	if settingCaptureMax != 23456 ||
+		SettingCaptureMax() != 23456 {
+		t.Fatalf(errMsg, EnvCaptureMax, settingCaptureMax, 23456)
+	}
+
+	if settingExecTimeout != 2*time.Minute ||
+		SettingExecTimeout() != 2*time.Minute {
+		t.Fatalf(errMsg, EnvExecTimeout, settingExecTimeout, 2*time.Minute)
+	}
 }
@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -40,10 +41,15 @@ const (
 	validPermFile     = "06oo"
 	validPermExe      = "07oo"
 	validTmpDir       = "valid directory"
+	validTmpIsolate   = "true | false"
+	validTmpPrune     = "duration >= 0"
+	validKeepTmp      = "never | on-failure | always"
 	validColor        = "valid color, style or custom"
 	validMinRunString = "1 <= x <= 5"
 	validMinRunSlice  = "1 <= x <= 5"
 	validBufferSize   = "x >= 1000"
+	validCaptureMax   = "0 | x >= 1000"
+	validExecTimeout  = "duration > 0"
 )
 
 func validateFailFast(rawSetting string) (bool, bool) {
@@ -124,6 +130,56 @@ func validateTmpDir(rawSetting string) (string, bool) {
 	return rawSetting, true
 }
 
+func validateTmpIsolate(rawSetting string) (bool, bool) {
+	switch strings.ToUpper(strings.TrimSpace(rawSetting)) {
+	case "TRUE":
+		return true, true
+	case "FALSE":
+		return false, true
+	}
+
+	log.Printf(errMsg, EnvTmpIsolate,
+		rawSetting,
+		validTmpIsolate,
+		defTmpIsolate,
+	)
+
+	return false, false
+}
+
+func validateTmpPrune(rawSetting string) (time.Duration, bool) {
+	age, err := time.ParseDuration(strings.TrimSpace(rawSetting))
+
+	if err != nil || age < 0 {
+		log.Printf(errMsg, EnvTmpPrune,
+			rawSetting,
+			validTmpPrune,
+			defTmpPrune,
+		)
+
+		return 0, false
+	}
+
+	return age, true
+}
+
+func validateKeepTmp(rawSetting string) (string, bool) {
+	keep := strings.ToLower(strings.TrimSpace(rawSetting))
+
+	switch keep {
+	case keepTmpNever, keepTmpOnFailure, keepTmpAlways:
+		return keep, true
+	}
+
+	log.Printf(errMsg, EnvKeepTmp,
+		rawSetting,
+		validKeepTmp,
+		defKeepTmp,
+	)
+
+	return "", false
+}
+
 //nolint:gochecknoglobals // Ok.
 var markStyles = map[string]string{
 	"DEFAULT":   clrOff,
@@ -343,3 +399,35 @@ func validateBufferSize(rawSetting string) (int, bool) {
 
 	return int(bufSize64), true
 }
+
+func validateCaptureMax(rawSetting string) (int, bool) {
+	captureMax64, err := strconv.ParseInt(rawSetting, base10, bits64)
+
+	if err != nil || (captureMax64 != 0 && captureMax64 < 1000) {
+		log.Printf(errMsg, EnvCaptureMax,
+			rawSetting,
+			validCaptureMax,
+			defCaptureMax,
+		)
+
+		return 0, false
+	}
+
+	return int(captureMax64), true
+}
+
+func validateExecTimeout(rawSetting string) (time.Duration, bool) {
+	timeout, err := time.ParseDuration(strings.TrimSpace(rawSetting))
+
+	if err != nil || timeout <= 0 {
+		log.Printf(errMsg, EnvExecTimeout,
+			rawSetting,
+			validExecTimeout,
+			defExecTimeout,
+		)
+
+		return 0, false
+	}
+
+	return timeout, true
+}
@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -34,6 +35,7 @@ const (
 	invalidPerm          = "unexpected %s: got: %4.4o want: %4.4o"
 	invalidString        = "unexpected %s:\ngot: %q\nwant: %q"
 	invalidInt           = "unexpected %s: got: %d want: %d"
+	invalidDuration      = "unexpected %s: got: %v want: %v"
 	invalidCaptureLength = "unexpected %s log output length: got: %d  want: %d"
 )
 
@@ -43,10 +45,15 @@ func testConfigValidate(t *testing.T) {
 	t.Run("PermFile", testConfigValidatePermFile)
 	t.Run("PermExe", testConfigValidatePermExe)
 	t.Run("TmpDir", testConfigValidateTmpDir)
+	t.Run("TmpIsolate", testConfigValidateTmpIsolate)
+	t.Run("TmpPrune", testConfigValidateTmpPrune)
+	t.Run("KeepTmp", testConfigValidateKeepTmp)
 	t.Run("Color", testConfigValidateColor)
 	t.Run("MinRunString", testConfigValidateMinRunString)
 	t.Run("MinRunSlice", testConfigValidateMinRunSlice)
 	t.Run("BufferSize", testConfigValidateBufferSize)
+	t.Run("CaptureMax", testConfigValidateCaptureMax)
+	t.Run("ExecTimeout", testConfigValidateExecTimeout)
 }
 
 func testConfigValidateFailFast(t *testing.T) {
@@ -742,3 +749,248 @@ func testConfigValidateBufferSize(t *testing.T) {
 		t.Fatalf(invalidString, jsonName, buf.String(), wLine)
 	}
 }
+
+func testConfigValidateCaptureMax(t *testing.T) {
+	buf := bytes.NewBuffer(make([]byte, 0, 1000))
+	log.SetOutput(buf)
+
+	defer log.SetOutput(os.Stderr)
+
+	const jsonName = "capture_max"
+
+	captureMaxValue, ok := validateCaptureMax("999")
+	if ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, false)
+	}
+
+	if captureMaxValue != 0 {
+		t.Fatalf(invalidInt, jsonName, captureMaxValue, 0)
+	}
+
+	captureMaxValue, ok = validateCaptureMax("0")
+	if !ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, true)
+	}
+
+	if captureMaxValue != 0 {
+		t.Fatalf(invalidInt, jsonName, captureMaxValue, 0)
+	}
+
+	captureMaxValue, ok = validateCaptureMax("15000")
+	if !ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, true)
+	}
+
+	if captureMaxValue != 15000 {
+		t.Fatalf(invalidInt, jsonName, captureMaxValue, 15000)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+
+	wLineLength := 2
+	if len(lines) != wLineLength || lines[wLineLength-1] != "" {
+		t.Fatalf(invalidCaptureLength, jsonName, len(lines), wLineLength)
+	}
+
+	wLine := fmt.Sprintf(
+		errMsg, EnvCaptureMax, "999", validCaptureMax, defCaptureMax,
+	)
+	if !strings.Contains(lines[0], wLine) {
+		t.Fatalf(invalidString, jsonName, buf.String(), wLine)
+	}
+}
+
+func testConfigValidateExecTimeout(t *testing.T) {
+	buf := bytes.NewBuffer(make([]byte, 0, 1000))
+	log.SetOutput(buf)
+
+	defer log.SetOutput(os.Stderr)
+
+	const jsonName = "exec_timeout"
+
+	timeoutValue, ok := validateExecTimeout("0s")
+	if ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, false)
+	}
+
+	if timeoutValue != 0 {
+		t.Fatalf(invalidDuration, jsonName, timeoutValue, 0)
+	}
+
+	timeoutValue, ok = validateExecTimeout("ten seconds")
+	if ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, false)
+	}
+
+	if timeoutValue != 0 {
+		t.Fatalf(invalidDuration, jsonName, timeoutValue, 0)
+	}
+
+	timeoutValue, ok = validateExecTimeout(" 1m30s ")
+	if !ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, true)
+	}
+
+	if timeoutValue != 90*time.Second {
+		t.Fatalf(invalidDuration, jsonName, timeoutValue, 90*time.Second)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+
+	wLineLength := 3
+	if len(lines) != wLineLength || lines[wLineLength-1] != "" {
+		t.Fatalf(invalidCaptureLength, jsonName, len(lines), wLineLength)
+	}
+
+	wLine := fmt.Sprintf(
+		errMsg, EnvExecTimeout, "0s", validExecTimeout, defExecTimeout,
+	)
+	if !strings.Contains(lines[0], wLine) {
+		t.Fatalf(invalidString, jsonName, buf.String(), wLine)
+	}
+}
+
+func testConfigValidateTmpIsolate(t *testing.T) {
+	buf := bytes.NewBuffer(make([]byte, 0, 1000))
+
+	log.SetOutput(buf)
+	defer log.SetOutput(os.Stderr)
+
+	const jsonName = "tmp_isolate"
+
+	isolateValue, ok := validateTmpIsolate(" TRUE ")
+	if !ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, true)
+	}
+
+	if !isolateValue {
+		t.Fatalf(invalidBool, jsonName, isolateValue, true)
+	}
+
+	isolateValue, ok = validateTmpIsolate("false")
+	if !ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, true)
+	}
+
+	if isolateValue {
+		t.Fatalf(invalidBool, jsonName, isolateValue, false)
+	}
+
+	isolateValue, ok = validateTmpIsolate("yes")
+	if ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, false)
+	}
+
+	if isolateValue {
+		t.Fatalf(invalidBool, jsonName, isolateValue, false)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	wLineLength := 2
+
+	if len(lines) != wLineLength || lines[wLineLength-1] != "" {
+		t.Fatalf(invalidCaptureLength, jsonName, len(lines), wLineLength)
+	}
+
+	wLine := fmt.Sprintf(
+		errMsg, EnvTmpIsolate, "yes", validTmpIsolate, defTmpIsolate,
+	)
+
+	if !strings.Contains(lines[0], wLine) {
+		t.Fatalf(invalidString, jsonName, buf.String(), wLine)
+	}
+}
+
+func testConfigValidateTmpPrune(t *testing.T) {
+	buf := bytes.NewBuffer(make([]byte, 0, 1000))
+	log.SetOutput(buf)
+
+	defer log.SetOutput(os.Stderr)
+
+	const jsonName = "tmp_prune"
+
+	pruneValue, ok := validateTmpPrune("-1h")
+	if ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, false)
+	}
+
+	if pruneValue != 0 {
+		t.Fatalf(invalidDuration, jsonName, pruneValue, 0)
+	}
+
+	pruneValue, ok = validateTmpPrune("0")
+	if !ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, true)
+	}
+
+	if pruneValue != 0 {
+		t.Fatalf(invalidDuration, jsonName, pruneValue, 0)
+	}
+
+	pruneValue, ok = validateTmpPrune("24h")
+	if !ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, true)
+	}
+
+	if pruneValue != 24*time.Hour {
+		t.Fatalf(invalidDuration, jsonName, pruneValue, 24*time.Hour)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+
+	wLineLength := 2
+	if len(lines) != wLineLength || lines[wLineLength-1] != "" {
+		t.Fatalf(invalidCaptureLength, jsonName, len(lines), wLineLength)
+	}
+
+	wLine := fmt.Sprintf(
+		errMsg, EnvTmpPrune, "-1h", validTmpPrune, defTmpPrune,
+	)
+	if !strings.Contains(lines[0], wLine) {
+		t.Fatalf(invalidString, jsonName, buf.String(), wLine)
+	}
+}
+
+func testConfigValidateKeepTmp(t *testing.T) {
+	buf := bytes.NewBuffer(make([]byte, 0, 1000))
+	log.SetOutput(buf)
+
+	defer log.SetOutput(os.Stderr)
+
+	const jsonName = "keep_tmp"
+
+	for _, keep := range []string{"never", " On-Failure ", "ALWAYS"} {
+		keepValue, ok := validateKeepTmp(keep)
+		if !ok {
+			t.Fatalf(invalidOkBool, jsonName, ok, true)
+		}
+
+		want := strings.ToLower(strings.TrimSpace(keep))
+		if keepValue != want {
+			t.Fatalf(invalidString, jsonName, keepValue, want)
+		}
+	}
+
+	keepValue, ok := validateKeepTmp("sometimes")
+	if ok {
+		t.Fatalf(invalidOkBool, jsonName, ok, false)
+	}
+
+	if keepValue != "" {
+		t.Fatalf(invalidString, jsonName, keepValue, "")
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+
+	wLineLength := 2
+	if len(lines) != wLineLength || lines[wLineLength-1] != "" {
+		t.Fatalf(invalidCaptureLength, jsonName, len(lines), wLineLength)
+	}
+
+	wLine := fmt.Sprintf(
+		errMsg, EnvKeepTmp, "sometimes", validKeepTmp, defKeepTmp,
+	)
+	if !strings.Contains(lines[0], wLine) {
+		t.Fatalf(invalidString, jsonName, buf.String(), wLine)
+	}
+}
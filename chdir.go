@@ -0,0 +1,85 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+)
+
+const workingDirTypeName = "working directory"
+
+// Chdir changes the current working directory to path, returning the
+// previous working directory so that files relative to the package (such as
+// testdata) remain reachable. The previous directory is restored when
+// chk.Release() is called. If the working directory was changed again by
+// anything other than a later chk.Chdir the test fails before it is
+// restored. Calls may be nested, each being undone in reverse order.
+func (chk *Chk) Chdir(path string) string {
+	chk.t.Helper()
+
+	origDir, err := os.Getwd()
+	if err == nil {
+		err = os.Chdir(path)
+	}
+
+	var newDir string
+
+	if err == nil {
+		newDir, err = os.Getwd()
+		if err != nil {
+			_ = os.Chdir(origDir)
+		}
+	}
+
+	if err != nil {
+		chk.Error("chdir cause: ", err)
+
+		return origDir
+	}
+
+	chk.PushPreReleaseFunc(func() error {
+		chk.t.Helper()
+
+		cwd, cwdErr := os.Getwd()
+
+		// Restored before reporting as failing the test may stop it
+		// (FailNow) before returning.
+		err := os.Chdir(origDir)
+
+		if cwdErr == nil && cwd != newDir {
+			chk.errGotWnt(
+				workingDirTypeName, cwd, newDir,
+				"changed outside of chk.Chdir",
+			)
+		}
+
+		return err //nolint:wrapcheck // Ok.
+	})
+
+	return origDir
+}
+
+// ChdirTmp changes the current working directory to the test’s root
+// temporary directory (see CreateTmpDir) as with Chdir, returning the
+// previous working directory. It is restored when chk.Release() is called.
+func (chk *Chk) ChdirTmp() string {
+	chk.t.Helper()
+
+	return chk.Chdir(chk.CreateTmpDir())
+}
@@ -0,0 +1,208 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tstChkChdir(t *testing.T) {
+	t.Run("Tmp", chkChdirTestTmp)
+	t.Run("Nested", chkChdirTestNested)
+	t.Run("ChangedOutside", chkChdirTestChangedOutside)
+	t.Run("ChangedOutsideFailNow", chkChdirTestChangedOutsideFailNow)
+	t.Run("Invalid", chkChdirTestInvalid)
+}
+
+func chkChdirTestTmp(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	origDir, err := os.Getwd()
+	chk.NoErr(err)
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	chk.Str(iChk.ChdirTmp(), origDir)
+
+	cwd, err := os.Getwd()
+	chk.NoErr(err)
+	chk.Str(cwd, iChk.CreateTmpDir())
+
+	chk.NoErr(os.WriteFile("relative.txt", []byte("data"), 0o600))
+	chk.FileContents(filepath.Join(cwd, "relative.txt"), "data")
+
+	iChk.Release()
+
+	cwd, err = os.Getwd()
+	chk.NoErr(err)
+	chk.Str(cwd, origDir)
+	chk.FileNotExists(iChk.CreateTmpDir())
+
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("ChdirTmp"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Chdir"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutHelper("Chdir.func1"),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+func chkChdirTestNested(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	origDir, err := os.Getwd()
+	chk.NoErr(err)
+
+	first := chk.CreateTmpSubDir("first")
+	second := chk.CreateTmpSubDir("second")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	chk.Str(iChk.Chdir(first), origDir)
+	chk.Str(iChk.Chdir(second), first)
+
+	iChk.Release()
+
+	cwd, err := os.Getwd()
+	chk.NoErr(err)
+	chk.Str(cwd, origDir)
+
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Chdir"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Chdir"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutHelper("Chdir.func1"),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("Chdir.func1"),
+	)
+}
+
+func chkChdirTestChangedOutside(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	origDir, err := os.Getwd()
+	chk.NoErr(err)
+
+	first := chk.CreateTmpSubDir("first")
+	other := chk.CreateTmpSubDir("other")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.Chdir(first)
+	chk.NoErr(os.Chdir(other))
+
+	iChk.Release()
+
+	cwd, err := os.Getwd()
+	chk.NoErr(err)
+	chk.Str(cwd, origDir)
+
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Chdir"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("Chdir.func1"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg("changed outside of chk.Chdir", "working directory"),
+			g(other),
+			w(first),
+		),
+	)
+}
+
+func chkChdirTestChangedOutsideFailNow(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	origDir, err := os.Getwd()
+	chk.NoErr(err)
+
+	first := chk.CreateTmpSubDir("first")
+	other := chk.CreateTmpSubDir("other")
+
+	gT := runGoexitTest(func(gT *gTst) {
+		gChk := CaptureNothing(gT)
+		defer gChk.Release()
+
+		gChk.Chdir(first)
+		chk.NoErr(os.Chdir(other))
+	})
+
+	// Restored even though reporting the change stopped the test.
+	cwd, err := os.Getwd()
+	chk.NoErr(err)
+	chk.Str(cwd, origDir)
+
+	chk.True(strings.Contains(gT.output, "changed outside of chk.Chdir"))
+	chk.True(strings.HasSuffix(gT.output, "Fail Now\n"))
+}
+
+func chkChdirTestInvalid(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	origDir, err := os.Getwd()
+	chk.NoErr(err)
+
+	missing := filepath.Join(settingTmpDir, "doesNotExist")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	chk.Str(iChk.Chdir(missing), origDir)
+
+	cwd, err := os.Getwd()
+	chk.NoErr(err)
+	chk.Str(cwd, origDir)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Chdir"),
+		chkOutError(
+			"chdir cause: chdir "+missing+": no such file or directory",
+		),
+		chkOutRelease(),
+	)
+}
@@ -19,7 +19,6 @@
 package sztest
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -128,43 +127,22 @@ type Chk struct {
 
 	// Output capture fields.
 
-	errBuf          *bytes.Buffer
+	errBuf          *captureBuf
 	errOrig         *os.File
-	logBuf          *bytes.Buffer
+	logBuf          *captureBuf
 	logOrig         io.Writer
 	logOrigLogFlags int
-	outBuf          *bytes.Buffer
+	outBuf          *captureBuf
 	outOrig         *os.File
 
 	faultCount uint
 	nextTmpID  int
 
-	// io.Reader and io.Writer field
-	rData   []byte
-	rPos    int
-	rLeft   int
-	rErrPos int
-	rErr    error
-
-	wData   []byte
-	wErrPos int
-	wErr    error
-
-	ioSeekErrPos int64
-	ioSeekErr    error
-
-	ioReadErrPos int
-	ioReadErr    error
-
-	ioWriteErrPos int
-	ioWriteErr    error
-
-	ioCloseErr error
-
-	ioSeekErrSet  bool
-	ioReadErrSet  bool
-	ioWriteErrSet bool
-	ioCloseErrSet bool
+	// Default fake used by the io.Reader, io.Writer, io.Seeker and io.Closer
+	// methods implemented directly on chk.
+	fakeIO         *FakeIO
+	fakeIOVerified bool
+	nextFakeID     int
 
 	runningPanicFunction bool
 
@@ -178,6 +156,10 @@ type Chk struct {
 
 	keepTmpFiles  bool
 	tmpDirCreated bool
+	tmpDirPath    string
+
+	// Directory of fake commands prepended to PATH by FakeCommand.
+	fakeBinDir string
 
 	clk      *tstClk
 	clkSub   ClkFmt
@@ -192,10 +174,8 @@ func newChk(t testingT, option captureOption) *Chk {
 
 	chk := new(Chk)
 	chk.t = t
-	chk.rData = make([]byte, 0)
-	chk.rErrPos = -1
-	chk.wData = make([]byte, 0)
-	chk.wErrPos = -1
+	chk.fakeIO = newFakeIO("chk")
+	chk.fakeIO.advance = chk.advanceClock
 	chk.clk = newTstClock(time.Now(), []time.Duration{time.Millisecond})
 	chk.markupForDisplay = resolveMarksForDisplay
 
@@ -284,7 +264,8 @@ func (chk *Chk) T() testingT {
 // KeepTmpFiles prevents automatic cleanup of the temporary
 // directory tree when the test completes successfully. Applies
 // only to the current test and is useful for debugging setups
-// by inspecting intermediate files.
+// by inspecting intermediate files. The location and contents of
+// the retained directory are logged on release.
 func (chk *Chk) KeepTmpFiles() {
 	chk.keepTmpFiles = true
 }
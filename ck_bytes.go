@@ -0,0 +1,264 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	hexDumpWidth    = 8
+	hexDumpTypeName = "[]byte"
+)
+
+type hexDumpMark int
+
+const (
+	hexDumpSame hexDumpMark = iota
+	hexDumpChg
+	hexDumpIns
+	hexDumpDel
+	hexDumpNone
+)
+
+func (m hexDumpMark) wrap(s string) string {
+	switch m { //nolint:exhaustive // Default handles unmarked cases.
+	case hexDumpChg:
+		return markChgOn + s + markChgOff
+	case hexDumpIns:
+		return markInsOn + s + markInsOff
+	case hexDumpDel:
+		return markDelOn + s + markDelOff
+	default:
+		return s
+	}
+}
+
+func hexDumpASCII(b byte) string {
+	if b < ' ' || b > '~' {
+		return "."
+	}
+
+	return string(b)
+}
+
+// hexDumpAlign marks each byte of got and want as unchanged, changed,
+// inserted (got only) or deleted (want only). The bytes are aligned with
+// the same longest run matching used by diffSlice so a single inserted or
+// deleted byte does not mark every later byte as changed. Common prefixes
+// and suffixes are matched directly to keep large dumps fast.
+func hexDumpAlign(got, want []byte, gotMarks, wantMarks []hexDumpMark) {
+	prefix := 0
+	for prefix < len(got) && prefix < len(want) &&
+		got[prefix] == want[prefix] {
+		//
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(got)-prefix && suffix < len(want)-prefix &&
+		got[len(got)-1-suffix] == want[len(want)-1-suffix] {
+		//
+		suffix++
+	}
+
+	got = got[prefix : len(got)-suffix]
+	want = want[prefix : len(want)-suffix]
+	gotMarks = gotMarks[prefix : len(gotMarks)-suffix]
+	wantMarks = wantMarks[prefix : len(wantMarks)-suffix]
+
+	gotIdx, wantIdx, numBytes := 0, 0, 0
+	if len(got) > 0 && len(want) > 0 {
+		gotIdx, wantIdx, numBytes = bestNextRun(
+			got, want, settingDiffChars, defaultCmpFunc[byte],
+		)
+	}
+
+	if numBytes == 0 {
+		// Nothing matched: pair bytes as changed with any excess on
+		// either side inserted or deleted.
+		for i := range gotMarks {
+			gotMarks[i] = hexDumpIns
+			if i < len(wantMarks) {
+				gotMarks[i] = hexDumpChg
+			}
+		}
+
+		for i := range wantMarks {
+			wantMarks[i] = hexDumpDel
+			if i < len(gotMarks) {
+				wantMarks[i] = hexDumpChg
+			}
+		}
+
+		return
+	}
+
+	hexDumpAlign(
+		got[:gotIdx], want[:wantIdx], gotMarks[:gotIdx], wantMarks[:wantIdx],
+	)
+	hexDumpAlign(
+		got[gotIdx+numBytes:], want[wantIdx+numBytes:],
+		gotMarks[gotIdx+numBytes:], wantMarks[wantIdx+numBytes:],
+	)
+}
+
+// hexDumpSide renders the hex and ASCII columns of one side of a row,
+// merging runs of identically marked bytes into a single markup span.
+// Positions past the end of data are padded with blanks.
+func hexDumpSide(data []byte, dataMarks []hexDumpMark, offset int) string {
+	marks := make([]hexDumpMark, hexDumpWidth)
+	hexCells := make([]string, hexDumpWidth)
+	asciiCells := make([]string, hexDumpWidth)
+
+	for i := range hexDumpWidth {
+		pos := offset + i
+
+		if pos >= len(data) {
+			marks[i] = hexDumpNone
+			hexCells[i] = "  "
+			asciiCells[i] = " "
+
+			continue
+		}
+
+		marks[i] = dataMarks[pos]
+		hexCells[i] = fmt.Sprintf("%02x", data[pos])
+		asciiCells[i] = hexDumpASCII(data[pos])
+	}
+
+	join := func(cells []string, sep string) string {
+		var (
+			result strings.Builder
+			run    strings.Builder
+		)
+
+		for i, cell := range cells {
+			if i > 0 && marks[i] != marks[i-1] {
+				result.WriteString(marks[i-1].wrap(run.String()))
+				run.Reset()
+				result.WriteString(sep)
+			} else if i > 0 {
+				run.WriteString(sep)
+			}
+
+			run.WriteString(cell)
+		}
+
+		result.WriteString(marks[hexDumpWidth-1].wrap(run.String()))
+
+		return result.String()
+	}
+
+	return join(hexCells, " ") + " |" + join(asciiCells, "") + "|"
+}
+
+// hexDumpDiff renders got and want as a side by side hexdump with the
+// offset, hex and ASCII columns of each. After aligning the two sides bytes
+// that differ are marked as changed while bytes present on only one side
+// are marked as inserted (got) or deleted (want). Long runs of identical
+// rows are collapsed.
+func hexDumpDiff(got, want []byte) string {
+	gotMarks := make([]hexDumpMark, len(got))
+	wantMarks := make([]hexDumpMark, len(want))
+	hexDumpAlign(got, want, gotMarks, wantMarks)
+
+	size := max(len(got), len(want))
+	sideWidth := hexDumpWidth*3 + hexDumpWidth + 2
+	rows := []string{fmt.Sprintf(
+		"%-8s  %-*s  %s", "offset", sideWidth, "got", "want",
+	)}
+	lines := make([]string, 0, size/hexDumpWidth+1)
+
+	for offset := 0; offset < size; offset += hexDumpWidth {
+		lines = append(lines, fmt.Sprintf(
+			"%08x  %s  %s",
+			offset,
+			hexDumpSide(got, gotMarks, offset),
+			hexDumpSide(want, wantMarks, offset),
+		))
+	}
+
+	rows = append(rows, windowDiff(lines)...)
+
+	return fmt.Sprint(
+		"Length Got: ", len(got), " Wnt: ", len(want),
+		" [\n", strings.Join(rows, "\n"), "\n]",
+	)
+}
+
+// Bytesf compares two byte slices for equality.
+//
+// A mismatch is reported as a side by side hexdump diff with a formatted
+// message built from msgFmt and msgArgs. Returns true if the slices are
+// exactly equal.
+func (chk *Chk) Bytesf(
+	got, want []byte, msgFmt string, msgArgs ...any,
+) bool {
+	if bytes.Equal(got, want) {
+		return true
+	}
+
+	chk.t.Helper()
+	chk.Error(
+		errMsgHeaderf(hexDumpTypeName, msgFmt, msgArgs...) +
+			hexDumpDiff(got, want),
+	)
+
+	return false
+}
+
+// Bytes compares two byte slices for equality.
+//
+// Unlike ByteSlice a mismatch is reported as a side by side hexdump diff
+// (offset, hex and ASCII columns) more suited to binary data. Optional msg
+// values are included in the failure output. Returns true if the slices are
+// exactly equal.
+func (chk *Chk) Bytes(got, want []byte, msg ...any) bool {
+	if bytes.Equal(got, want) {
+		return true
+	}
+
+	chk.t.Helper()
+	chk.Error(
+		errMsgHeader(hexDumpTypeName, msg...) + hexDumpDiff(got, want),
+	)
+
+	return false
+}
+
+// IOWriterBytesf compares everything written to the chk io.Writer against
+// want reporting a mismatch as with Bytesf.
+func (chk *Chk) IOWriterBytesf(
+	want []byte, msgFmt string, msgArgs ...any,
+) bool {
+	chk.t.Helper()
+
+	return chk.Bytesf(chk.GetIOWriterData(), want, msgFmt, msgArgs...)
+}
+
+// IOWriterBytes compares everything written to the chk io.Writer against
+// want reporting a mismatch as a side by side hexdump diff as with Bytes.
+func (chk *Chk) IOWriterBytes(want []byte, msg ...any) bool {
+	chk.t.Helper()
+
+	return chk.Bytes(chk.GetIOWriterData(), want, msg...)
+}
@@ -0,0 +1,229 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"testing"
+)
+
+func tstChkBytes(t *testing.T) {
+	t.Run("Good", chkBytesTestGood)
+	t.Run("Changed", chkBytesTestChanged)
+	t.Run("Extra", chkBytesTestExtra)
+	t.Run("Missing", chkBytesTestMissing)
+	t.Run("Inserted", chkBytesTestInserted)
+	t.Run("Collapsed", chkBytesTestCollapsed)
+	t.Run("IOWriter", chkBytesTestIOWriter)
+}
+
+const tstHexDumpHeader = "" +
+	"offset    got                                 want"
+
+func chkBytesTestGood(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.Bytes(nil, []byte{})
+	chk.Bytes([]byte("abc"), []byte("abc"), "not ", "displayed")
+	chk.Bytesf([]byte{0, 1, 2}, []byte{0, 1, 2}, "not %s", "displayed")
+
+	_, _ = chk.Write([]byte("data"))
+
+	chk.IOWriterBytes([]byte("data"))
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("IOWriterBytes"),
+		chkOutRelease(),
+	)
+}
+
+func chkBytesTestChanged(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.Bytes([]byte("abc"), []byte("abX"), "msg ", 1)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Bytes"),
+		chkOutError(
+			chkOutCommonMsg("msg 1", "[]byte"),
+			"Length Got: 3 Wnt: 3 [",
+			tstHexDumpHeader,
+			"00000000  61 62 "+markAsChg("63", "", diffGot)+
+				"                |ab"+markAsChg("c", "", diffGot)+"     |"+
+				"  61 62 "+markAsChg("", "58", diffWant)+
+				"                |ab"+markAsChg("", "X", diffWant)+"     |",
+			"]",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkBytesTestExtra(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.Bytesf(
+		[]byte("0123456789"), []byte("01234567"), "msg %d", 2,
+	)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Bytesf"),
+		chkOutError(
+			chkOutCommonMsg("msg 2", "[]byte"),
+			"Length Got: 10 Wnt: 8 [",
+			tstHexDumpHeader,
+			"00000000  30 31 32 33 34 35 36 37 |01234567|"+
+				"  30 31 32 33 34 35 36 37 |01234567|",
+			"00000008  "+markAsIns("38 39")+"                   |"+
+				markAsIns("89")+"      |"+
+				"                          |        |",
+			"]",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkBytesTestMissing(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.Bytes([]byte{'a', 0}, []byte{'a', 0, 0xff})
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Bytes"),
+		chkOutError(
+			chkOutCommonMsg("", "[]byte"),
+			"Length Got: 2 Wnt: 3 [",
+			tstHexDumpHeader,
+			"00000000  61 00                   |a.      |"+
+				"  61 00 "+markAsDel("ff")+"                |a."+
+				markAsDel(".")+"     |",
+			"]",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkBytesTestInserted(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.Bytes([]byte("abXcdefgh"), []byte("abcdefgh"))
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Bytes"),
+		chkOutError(
+			chkOutCommonMsg("", "[]byte"),
+			"Length Got: 9 Wnt: 8 [",
+			tstHexDumpHeader,
+			"00000000  61 62 "+markAsIns("58")+" 63 64 65 66 67 |ab"+
+				markAsIns("X")+"cdefg|"+
+				"  61 62 63 64 65 66 67 68 |abcdefgh|",
+			"00000008  68                      |h       |"+
+				"                          |        |",
+			"]",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkBytesTestCollapsed(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	got := make([]byte, 80)
+	want := make([]byte, 80)
+	want[40] = '!'
+
+	const zeroRow = "" +
+		"00 00 00 00 00 00 00 00 |........|" +
+		"  00 00 00 00 00 00 00 00 |........|"
+
+	chk.Bytes(got, want)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Bytes"),
+		chkOutError(
+			chkOutCommonMsg("", "[]byte"),
+			"Length Got: 80 Wnt: 80 [",
+			tstHexDumpHeader,
+			"... 2 unchanged lines ...",
+			"00000010  "+zeroRow,
+			"00000018  "+zeroRow,
+			"00000020  "+zeroRow,
+			"00000028  "+markAsChg("00", "", diffGot)+
+				" 00 00 00 00 00 00 00 |"+markAsChg(".", "", diffGot)+
+				".......|  "+markAsChg("", "21", diffWant)+
+				" 00 00 00 00 00 00 00 |"+markAsChg("", "!", diffWant)+
+				".......|",
+			"00000030  "+zeroRow,
+			"00000038  "+zeroRow,
+			"00000040  "+zeroRow,
+			"00000048  "+zeroRow,
+			"]",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkBytesTestIOWriter(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	_, _ = chk.Write([]byte("ab"))
+
+	chk.IOWriterBytesf([]byte("a"), "written %s", "data")
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("IOWriterBytesf"),
+		chkOutHelper("Bytesf"),
+		chkOutError(
+			chkOutCommonMsg("written data", "[]byte"),
+			"Length Got: 2 Wnt: 1 [",
+			tstHexDumpHeader,
+			"00000000  61 "+markAsIns("62")+"                   |a"+
+				markAsIns("b")+"      |"+
+				"  61                      |a       |",
+			"]",
+		),
+		chkOutRelease(),
+	)
+}
@@ -0,0 +1,271 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+const (
+	fileTypeName     = "file"
+	fileModeTypeName = "file mode"
+	symlinkTypeName  = "symlink"
+
+	fileFound    = "exists"
+	fileNotFound = "does not exist"
+)
+
+// fileMsgf builds the failure message for the formatted file checks: the
+// formatted message followed by the path.
+func fileMsgf(path, msgFmt string, msgArgs ...any) string {
+	msg := fmt.Sprintf(msgFmt, msgArgs...)
+	if msg == "" {
+		return path
+	}
+
+	return msg + ": " + path
+}
+
+// fileContents compares the contents of the file at path against wantLines
+// reporting any difference under the provided file header.
+func (chk *Chk) fileContents(
+	header, path string, wantLines ...string,
+) bool {
+	chk.t.Helper()
+
+	data, err := os.ReadFile(path) //nolint:gosec // Test assertion.
+	if err != nil {
+		chk.Error(header + err.Error())
+
+		return false
+	}
+
+	return chk.compareLog(
+		header, "", string(data), 0, keepLine, keepLine, wantLines...,
+	)
+}
+
+// FileContentsf compares the contents of the file at path against wantLines
+// as with FileContents. A mismatch is reported with a formatted message
+// built from msgFmt and msgArgs ahead of the path. Returns true if the
+// contents match.
+func (chk *Chk) FileContentsf(
+	path string, wantLines []string, msgFmt string, msgArgs ...any,
+) bool {
+	chk.t.Helper()
+
+	return chk.fileContents(
+		errMsgHeader(fileTypeName, fileMsgf(path, msgFmt, msgArgs...)),
+		path,
+		wantLines...,
+	)
+}
+
+// FileContents compares the contents of the file at path against
+// wantLines using the same pipeline as Stdout: the file is split into
+// lines, substitutions are applied to both sides and any difference is
+// reported as a line diff. A single trailing newline in the file is
+// ignored so the expected contents may be supplied via TrimAll. Returns
+// true if the contents match.
+func (chk *Chk) FileContents(path string, wantLines ...string) bool {
+	chk.t.Helper()
+
+	return chk.fileContents(
+		errMsgHeader(fileTypeName, path), path, wantLines...,
+	)
+}
+
+func fileModeGot(path string, want fs.FileMode) (string, bool) {
+	info, err := os.Stat(path)
+	if err == nil && info.Mode() == want {
+		return "", true
+	}
+
+	got := fileNotFound
+	if err == nil {
+		got = info.Mode().String()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		got = err.Error()
+	}
+
+	return got, false
+}
+
+// FileModef compares the mode of the file at path against want as with
+// FileMode. A mismatch is reported with a formatted message built from
+// msgFmt and msgArgs ahead of the path. Returns true if the modes match.
+func (chk *Chk) FileModef(
+	path string, want fs.FileMode, msgFmt string, msgArgs ...any,
+) bool {
+	got, ok := fileModeGot(path, want)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(
+		fileModeTypeName, got, want.String(),
+		fileMsgf(path, msgFmt, msgArgs...),
+	)
+}
+
+// FileMode compares the mode of the file at path (following symbolic links)
+// against want. Both the type bits and permissions are compared so a
+// directory must be given as fs.ModeDir|perm. Returns true if the modes
+// match.
+func (chk *Chk) FileMode(path string, want fs.FileMode) bool {
+	got, ok := fileModeGot(path, want)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(fileModeTypeName, got, want.String(), path)
+}
+
+func fileExistsGot(path string) (string, bool) {
+	_, err := os.Lstat(path)
+	if err == nil {
+		return "", true
+	}
+
+	got := fileNotFound
+	if !errors.Is(err, fs.ErrNotExist) {
+		got = err.Error()
+	}
+
+	return got, false
+}
+
+// FileExistsf checks that something exists at path as with FileExists. A
+// failure is reported with a formatted message built from msgFmt and
+// msgArgs ahead of the path. Returns true if it does.
+func (chk *Chk) FileExistsf(path, msgFmt string, msgArgs ...any) bool {
+	got, ok := fileExistsGot(path)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(
+		fileTypeName, got, fileFound, fileMsgf(path, msgFmt, msgArgs...),
+	)
+}
+
+// FileExists checks that something (a file, directory or symbolic link,
+// which need not resolve) exists at path. Returns true if it does.
+func (chk *Chk) FileExists(path string) bool {
+	got, ok := fileExistsGot(path)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(fileTypeName, got, fileFound, path)
+}
+
+func fileNotExistsGot(path string) (string, bool) {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", true
+	}
+
+	if err == nil {
+		return fileFound + " (" + info.Mode().String() + ")", false
+	}
+
+	return err.Error(), false
+}
+
+// FileNotExistsf checks that nothing exists at path as with FileNotExists.
+// A failure is reported with a formatted message built from msgFmt and
+// msgArgs ahead of the path. Returns true if nothing does.
+func (chk *Chk) FileNotExistsf(path, msgFmt string, msgArgs ...any) bool {
+	got, ok := fileNotExistsGot(path)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(
+		fileTypeName, got, fileNotFound, fileMsgf(path, msgFmt, msgArgs...),
+	)
+}
+
+// FileNotExists checks that nothing exists at path. Returns true if
+// nothing does.
+func (chk *Chk) FileNotExists(path string) bool {
+	got, ok := fileNotExistsGot(path)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(fileTypeName, got, fileNotFound, path)
+}
+
+func symlinkGot(path, target string) (string, bool) {
+	got, err := os.Readlink(path)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	return got, got == target
+}
+
+// Symlinkf checks that path is a symbolic link pointing to target as with
+// Symlink. A failure is reported with a formatted message built from
+// msgFmt and msgArgs ahead of the path. Returns true if it matches.
+func (chk *Chk) Symlinkf(
+	path, target string, msgFmt string, msgArgs ...any,
+) bool {
+	got, ok := symlinkGot(path, target)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(
+		symlinkTypeName, got, target, fileMsgf(path, msgFmt, msgArgs...),
+	)
+}
+
+// Symlink checks that path is a symbolic link pointing to target. The link
+// text is compared as stored without resolving it. Returns true if it
+// matches.
+func (chk *Chk) Symlink(path, target string) bool {
+	got, ok := symlinkGot(path, target)
+	if ok {
+		return true
+	}
+
+	chk.t.Helper()
+
+	return chk.errGotWnt(symlinkTypeName, got, target, path)
+}
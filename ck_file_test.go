@@ -0,0 +1,306 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func tstChkFile(t *testing.T) {
+	t.Run("Good", chkFileTestGood)
+	t.Run("ContentsBad", chkFileTestContentsBad)
+	t.Run("ContentsMissing", chkFileTestContentsMissing)
+	t.Run("ModeBad", chkFileTestModeBad)
+	t.Run("ExistsBad", chkFileTestExistsBad)
+	t.Run("SymlinkBad", chkFileTestSymlinkBad)
+	t.Run("Formatted", chkFileTestFormatted)
+}
+
+func chkFileTestGood(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- a.txt --\n" +
+		"line 1\n" +
+		"line 2\n" +
+		"-- empty.txt --\n" +
+		"-- dir/ --\n",
+	)
+	aPath := filepath.Join(root, "a.txt")
+	link := filepath.Join(root, "link")
+
+	chk.NoErr(os.Symlink("a.txt", link))
+
+	chk.FileContents(aPath, "line 1", "line 2")
+	chk.FileContents(aPath, chk.TrimAll(`
+      line 1
+      line 2
+	`))
+	chk.FileContents(filepath.Join(root, "empty.txt"))
+
+	chk.FileMode(aPath, 0o600)
+	chk.FileMode(filepath.Join(root, "dir"), fs.ModeDir|0o700)
+	chk.FileMode(link, 0o600)
+
+	chk.FileExists(aPath)
+	chk.FileExists(link)
+	chk.FileNotExists(filepath.Join(root, "missing"))
+
+	chk.Symlink(link, "a.txt")
+}
+
+func chkFileTestContentsBad(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fPath := chk.CreateTmpFileAs("", "a.txt", []byte("same\ngot\n"))
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.FileContents(fPath, "same", "want", "extra")
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("FileContents"),
+		chkOutHelper("fileContents"),
+		chkOutHelper("compareLog"),
+		chkOutError(
+			chkOutCommonMsg(fPath, "file"),
+			"got (2 lines) - want (3 lines)",
+			chkOutLnSame("0", "0", "same"),
+			chkOutLnChanged("1", "1", "got", "want"),
+			chkOutLnWnt("2", "extra"),
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkFileTestContentsMissing(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	fPath := filepath.Join(settingTmpDir, "doesNotExist")
+
+	chk.FileContents(fPath, "anything")
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("FileContents"),
+		chkOutHelper("fileContents"),
+		chkOutError(
+			chkOutCommonMsg(fPath, "file"),
+			"open "+fPath+": no such file or directory",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkFileTestModeBad(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	fPath := chk.CreateTmpFileAs("", "a.txt", nil)
+	missing := filepath.Join(settingTmpDir, "doesNotExist")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.FileMode(fPath, 0o644)
+	iChk.FileMode(missing, 0o644)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("FileMode"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(fPath, "file mode"),
+			g("-rw-------"),
+			w("-rw-r--r--"),
+		),
+		chkOutHelper("FileMode"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(missing, "file mode"),
+			g("does not exist"),
+			w("-rw-r--r--"),
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkFileTestExistsBad(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	fPath := chk.CreateTmpFileAs("", "a.txt", nil)
+	missing := filepath.Join(settingTmpDir, "doesNotExist")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.FileExists(missing)
+	iChk.FileNotExists(fPath)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("FileExists"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(missing, "file"),
+			g("does not exist"),
+			w("exists"),
+		),
+		chkOutHelper("FileNotExists"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(fPath, "file"),
+			g("exists (-rw-------)"),
+			w("does not exist"),
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkFileTestSymlinkBad(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fPath := chk.CreateTmpFileAs("", "a.txt", nil)
+	link := filepath.Join(chk.CreateTmpDir(), "link")
+
+	chk.NoErr(os.Symlink("b.txt", link))
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.Symlink(link, "a.txt")
+	iChk.Symlink(fPath, "a.txt")
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Symlink"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(link, "symlink"),
+			g("b.txt"),
+			w("a.txt"),
+		),
+		chkOutHelper("Symlink"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(fPath, "symlink"),
+			g("readlink "+fPath+": invalid argument"),
+			w("a.txt"),
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkFileTestFormatted(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	fPath := chk.CreateTmpFileAs("", "a.txt", []byte("got\n"))
+	missing := filepath.Join(settingTmpDir, "doesNotExist")
+
+	chk.FileContentsf(fPath, []string{"got"}, "msg %d", 1)
+	chk.FileModef(fPath, 0o600, "msg %d", 2)
+	chk.FileExistsf(fPath, "msg %d", 3)
+	chk.FileNotExistsf(missing, "msg %d", 4)
+
+	link := filepath.Join(chk.CreateTmpDir(), "link")
+	chk.NoErr(os.Symlink("a.txt", link))
+	chk.Symlinkf(link, "a.txt", "msg %d", 5)
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.FileContentsf(fPath, []string{"want"}, "msg %d", 1)
+	iChk.FileModef(fPath, 0o644, "msg %d", 2)
+	iChk.FileExistsf(missing, "msg %d", 3)
+	iChk.FileNotExistsf(fPath, "msg %d", 4)
+	iChk.Symlinkf(fPath, "a.txt", "")
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("FileContentsf"),
+		chkOutHelper("fileContents"),
+		chkOutHelper("compareLog"),
+		chkOutError(
+			chkOutCommonMsg("msg 1: "+fPath, "file"),
+			"got (1 lines) - want (1 lines)",
+			chkOutLnChanged("0", "0", "got", "want"),
+		),
+		chkOutHelper("FileModef"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg("msg 2: "+fPath, "file mode"),
+			g("-rw-------"),
+			w("-rw-r--r--"),
+		),
+		chkOutHelper("FileExistsf"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg("msg 3: "+missing, "file"),
+			g("does not exist"),
+			w("exists"),
+		),
+		chkOutHelper("FileNotExistsf"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg("msg 4: "+fPath, "file"),
+			g("exists (-rw-------)"),
+			w("does not exist"),
+		),
+		chkOutHelper("Symlinkf"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(fPath, "symlink"),
+			g("readlink "+fPath+": invalid argument"),
+			w("a.txt"),
+		),
+		chkOutRelease(),
+	)
+}
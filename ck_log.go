@@ -19,7 +19,6 @@
 package sztest
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"log"
@@ -31,6 +30,10 @@ import (
 
 const pauseTimeForLogToCatchup = time.Millisecond * 5
 
+// Number of unchanged lines kept on either side of a difference when
+// reporting captured output mismatches.
+const captureDiffContext = 3
+
 // Log package prefix elements.
 const (
 	logDate   = `\d\d\d\d\/\d\d\/\d\d\s`
@@ -76,12 +79,8 @@ func (chk *Chk) setupLoggers(option captureOption) {
 func (chk *Chk) setupStderrLogger(includeLog bool) {
 	chk.t.Helper()
 
-	var errBuf bytes.Buffer
-
-	errBuf.Grow(settingBufferSize)
-
 	chk.errOn = true
-	chk.errBuf = &errBuf
+	chk.errBuf = newCaptureBuf()
 	chk.errOrig = os.Stderr
 	_ = chk.copyStderr()
 
@@ -118,12 +117,8 @@ func (chk *Chk) setupStderrLogger(includeLog bool) {
 func (chk *Chk) setupLogLogger() {
 	chk.t.Helper()
 
-	var logBuf bytes.Buffer
-
-	logBuf.Grow(settingBufferSize)
-
 	chk.logOn = true
-	chk.logBuf = &logBuf
+	chk.logBuf = newCaptureBuf()
 	chk.logOrigLogFlags = log.Flags()
 	chk.logOrig = log.Writer()
 	log.SetFlags(0)
@@ -148,12 +143,8 @@ func (chk *Chk) setupLogLogger() {
 func (chk *Chk) setupStdoutLogger() {
 	chk.t.Helper()
 
-	var outBuf bytes.Buffer
-
-	outBuf.Grow(settingBufferSize)
-
 	chk.outOn = true
-	chk.outBuf = &outBuf
+	chk.outBuf = newCaptureBuf()
 	chk.outOrig = os.Stdout
 	_ = chk.copyStdout()
 
@@ -380,8 +371,76 @@ func (chk *Chk) prepareSlice(
 	return lines
 }
 
+// truncationNote describes output dropped once the capture limit was hit.
+func truncationNote(discarded int) string {
+	if discarded == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(
+		"\ncapture truncated: ", discarded,
+		" bytes discarded after reaching the limit of ", settingCaptureMax,
+		" bytes",
+	)
+}
+
+// windowDiff reduces long runs of unchanged lines in a slice diff to the
+// lines immediately surrounding the differences.
+func windowDiff(diffLines []string) []string {
+	isSame := func(line string) bool {
+		return !strings.Contains(line, markChgOn) &&
+			!strings.Contains(line, markInsOn) &&
+			!strings.Contains(line, markDelOn)
+	}
+
+	result := make([]string, 0, len(diffLines))
+	total := len(diffLines)
+
+	for i := 0; i < total; {
+		if !isSame(diffLines[i]) {
+			result = append(result, diffLines[i])
+			i++
+
+			continue
+		}
+
+		end := i
+		for end < total && isSame(diffLines[end]) {
+			end++
+		}
+
+		head := captureDiffContext
+		if i == 0 {
+			head = 0
+		}
+
+		tail := captureDiffContext
+		if end == total {
+			tail = 0
+		}
+
+		if end-i <= head+tail+1 {
+			result = append(result, diffLines[i:end]...)
+		} else {
+			result = append(result, diffLines[i:i+head]...)
+			result = append(result, fmt.Sprint(
+				"... ", end-i-head-tail, " unchanged lines ...",
+			))
+			result = append(result, diffLines[end-tail:end]...)
+		}
+
+		i = end
+	}
+
+	return result
+}
+
+// compareLog reports any line differences between got and wantLines after
+// applying the filters. The diff is titled with name, when not empty, and
+// preceded by header.
 func (chk *Chk) compareLog(
-	name, got string,
+	header, name, got string,
+	discarded int,
 	gotFilter, wantFilter func(string) string,
 	wantLines ...string,
 ) bool {
@@ -393,8 +452,13 @@ func (chk *Chk) compareLog(
 		gotSlice = []string{strings.TrimSuffix(got, "\n")}
 	}
 
+	title := ""
+	if name != "" {
+		title = fmt.Sprint("Unexpected ", name, " Entry")
+	}
+
 	ret := compareSlices(
-		fmt.Sprint("Unexpected ", name, " Entry"),
+		title,
 		chk.prepareSlice(
 			gotFilter,
 			gotSlice...,
@@ -410,7 +474,12 @@ func (chk *Chk) compareLog(
 	)
 
 	if ret != "" {
-		chk.Error(ret)
+		lines := strings.Split(ret, "\n")
+		lines = append(lines[:1], windowDiff(lines[1:])...)
+
+		chk.Error(
+			header + strings.Join(lines, "\n") + truncationNote(discarded),
+		)
 
 		return false
 	}
@@ -470,6 +539,63 @@ func removeLogPrefixes(line string) string {
 	return clearLogPrefix.ReplaceAllString(line, "")
 }
 
+func keepLine(line string) string {
+	return line
+}
+
+// logSource resolves the buffer holding the logger output along with the
+// name used in reports and the filter applied to the captured lines.  A nil
+// buffer is returned if the logger is not being captured.
+func (chk *Chk) logSource() (string, *captureBuf, func(string) string) {
+	if chk.logOn {
+		return "log", chk.logBuf, removeLogPrefixes
+	}
+
+	if chk.errOn && chk.errIncLog {
+		return "logWithStderr", chk.errBuf, removeLogPrefixes
+	}
+
+	return "", nil, nil
+}
+
+// stderrSource resolves the buffer holding the os.Stderr output along with
+// the name used in reports and the filter applied to the captured lines.  A
+// nil buffer is returned if os.Stderr is not being captured.
+func (chk *Chk) stderrSource() (string, *captureBuf, func(string) string) {
+	if !chk.errOn {
+		return "", nil, nil
+	}
+
+	if chk.errIncLog {
+		return "logWithStderr", chk.errBuf, removeLogPrefixes
+	}
+
+	return "stderr", chk.errBuf, keepLine
+}
+
+// stdoutSource resolves the buffer holding the os.Stdout output along with
+// the name used in reports and the filter applied to the captured lines.  A
+// nil buffer is returned if os.Stdout is not being captured.
+func (chk *Chk) stdoutSource() (string, *captureBuf, func(string) string) {
+	if !chk.outOn {
+		return "", nil, nil
+	}
+
+	return "stdout", chk.outBuf, keepLine
+}
+
+// markChecked records that the stream collected into capBuf was verified.
+func (chk *Chk) markChecked(capBuf *captureBuf) {
+	switch capBuf {
+	case chk.logBuf:
+		chk.logChecked = true
+	case chk.errBuf:
+		chk.errChecked = true
+	case chk.outBuf:
+		chk.outChecked = true
+	}
+}
+
 // Log compares the internally captured logger output against wantLines.
 //
 // Any decorations applied by the standard library log package (such as
@@ -483,7 +609,8 @@ func removeLogPrefixes(line string) string {
 func (chk *Chk) Log(wantLines ...string) bool {
 	chk.t.Helper()
 
-	if !chk.logOn && !(chk.errOn && chk.errIncLog) {
+	name, capBuf, gotFilter := chk.logSource()
+	if capBuf == nil {
 		chk.Error(
 			"invalid log.Writer check without information being captured",
 		)
@@ -491,31 +618,55 @@ func (chk *Chk) Log(wantLines ...string) bool {
 		return true
 	}
 
+	chk.markChecked(capBuf)
+
 	time.Sleep(pauseTimeForLogToCatchup)
 
-	var (
-		gotString string
-		name      string
+	got, discarded := capBuf.snapshot()
+
+	return chk.compareLog(
+		"",
+		name,
+		got,
+		discarded,
+		gotFilter,
+		keepLine,
+		wantLines...,
 	)
+}
 
-	if chk.logOn {
-		chk.logChecked = true
-		name = "log"
-		gotString = chk.logBuf.String()
-	} else {
-		chk.errChecked = true
-		name = "logWithStderr"
-		gotString = chk.errBuf.String()
+// LogNext compares the logger output captured since the last consuming
+// check (or since the Chk was created) against wantLines and then drains
+// it. Later calls to Log or LogNext only see output produced afterwards,
+// allowing multi-phase tests to assert on each step separately.
+//
+// Log prefixes are stripped exactly as with Log. Returns true when the
+// captured lines match exactly the supplied sequence.
+func (chk *Chk) LogNext(wantLines ...string) bool {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.logSource()
+	if capBuf == nil {
+		chk.Error(
+			"invalid log.Writer check without information being captured",
+		)
+
+		return true
 	}
 
+	chk.markChecked(capBuf)
+
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	got, discarded := capBuf.consume()
+
 	return chk.compareLog(
+		"",
 		name,
-		gotString,
-		removeLogPrefixes,
-		func(s string) string {
-			return s
-		},
-		//		prepareWantString,
+		got,
+		discarded,
+		gotFilter,
+		keepLine,
 		wantLines...,
 	)
 }
@@ -527,39 +678,60 @@ func (chk *Chk) Log(wantLines ...string) bool {
 func (chk *Chk) Stderr(wantLines ...string) bool {
 	chk.t.Helper()
 
-	if !(chk.errOn) {
+	name, capBuf, gotFilter := chk.stderrSource()
+	if capBuf == nil {
 		chk.Error("invalid os.Stderr check without information being captured")
 
 		return true
 	}
 
+	chk.markChecked(capBuf)
+
 	time.Sleep(pauseTimeForLogToCatchup)
 
-	chk.errChecked = true
+	got, discarded := capBuf.snapshot()
 
-	var (
-		getFilterFunc func(string) string
-		name          string
+	return chk.compareLog(
+		"",
+		name,
+		got,
+		discarded,
+		gotFilter,
+		keepLine,
+		wantLines...,
 	)
+}
 
-	if chk.errIncLog {
-		name = "logWithStderr"
-		getFilterFunc = removeLogPrefixes
-	} else {
-		name = "stderr"
-		getFilterFunc = func(s string) string {
-			return s
-		}
+// StderrNext compares the stderr output captured since the last consuming
+// check (or since the Chk was created) against wantLines and then drains
+// it. Later calls to Stderr or StderrNext only see output produced
+// afterwards.
+//
+// It returns true on an exact match and reports test failures via the Chk's
+// testingT.
+func (chk *Chk) StderrNext(wantLines ...string) bool {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.stderrSource()
+	if capBuf == nil {
+		chk.Error("invalid os.Stderr check without information being captured")
+
+		return true
 	}
 
+	chk.markChecked(capBuf)
+
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	got, discarded := capBuf.consume()
+
 	return chk.compareLog(
+		"",
 		name,
-		chk.errBuf.String(),
-		getFilterFunc,
-		func(s string) string {
-			return s
-		},
-		//		prepareWantString,
+		got,
+		discarded,
+		gotFilter,
+		keepLine,
 		wantLines...,
 	)
 }
@@ -571,26 +743,73 @@ func (chk *Chk) Stderr(wantLines ...string) bool {
 func (chk *Chk) Stdout(wantLines ...string) bool {
 	chk.t.Helper()
 
-	if !(chk.outOn) {
+	name, capBuf, gotFilter := chk.stdoutSource()
+	if capBuf == nil {
+		chk.Error("invalid os.Stdout check without information being captured")
+
+		return true
+	}
+
+	chk.markChecked(capBuf)
+
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	got, discarded := capBuf.snapshot()
+
+	return chk.compareLog(
+		"",
+		name,
+		got,
+		discarded,
+		gotFilter,
+		keepLine,
+		wantLines...,
+	)
+}
+
+// StdoutNext compares the stdout output captured since the last consuming
+// check (or since the Chk was created) against wantLines and then drains
+// it. Later calls to Stdout or StdoutNext only see output produced
+// afterwards.
+//
+// It returns true on an exact match and reports test failures via the Chk's
+// testingT.
+func (chk *Chk) StdoutNext(wantLines ...string) bool {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.stdoutSource()
+	if capBuf == nil {
 		chk.Error("invalid os.Stdout check without information being captured")
 
 		return true
 	}
 
+	chk.markChecked(capBuf)
+
 	time.Sleep(pauseTimeForLogToCatchup)
 
-	chk.outChecked = true
+	got, discarded := capBuf.consume()
 
 	return chk.compareLog(
-		"stdout",
-		chk.outBuf.String(),
-		func(s string) string {
-			return s
-		},
-		func(s string) string {
-			return s
-		},
-		//		prepareWantString,
+		"",
+		name,
+		got,
+		discarded,
+		gotFilter,
+		keepLine,
 		wantLines...,
 	)
 }
+
+// ResetCapture discards all output captured so far on every stream being
+// captured (logger, os.Stderr and os.Stdout). It does not count as a check
+// so the streams must still be verified before chk.Release().
+func (chk *Chk) ResetCapture() {
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	for _, capBuf := range []*captureBuf{chk.logBuf, chk.errBuf, chk.outBuf} {
+		if capBuf != nil {
+			capBuf.reset()
+		}
+	}
+}
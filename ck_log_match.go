@@ -0,0 +1,467 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type lineMatchKind int
+
+const (
+	lineMatchLiteral lineMatchKind = iota
+	lineMatchRegexp
+	lineMatchAny
+	lineMatchUnordered
+)
+
+const (
+	lineMatchAnyLine  = "<any line>"
+	lineMatchAnyLines = "<any lines>"
+)
+
+// LineMatch describes how one or more captured lines are matched by the
+// StdoutMatch, StderrMatch and LogMatch checks. Create entries with
+// MatchLine, MatchRegexp, MatchAny, MatchAnyLines or MatchUnordered.
+type LineMatch struct {
+	kind  lineMatchKind
+	lines []string
+	re    *regexp.Regexp
+	count int
+}
+
+// MatchLine matches exactly one captured line equal to line after
+// substitutions. Plain strings passed to the matching checks are treated
+// the same way.
+func MatchLine(line string) LineMatch {
+	return LineMatch{
+		kind:  lineMatchLiteral,
+		lines: []string{line},
+	}
+}
+
+// MatchRegexp matches exactly one captured line against the regular
+// expression expr. The expression is applied to the line after any
+// substitutions have been made. It panics if expr does not compile.
+func MatchRegexp(expr string) LineMatch {
+	return LineMatch{
+		kind: lineMatchRegexp,
+		re:   regexp.MustCompile(expr),
+	}
+}
+
+// MatchAny matches exactly count captured lines regardless of content.
+func MatchAny(count int) LineMatch {
+	return LineMatch{
+		kind:  lineMatchAny,
+		count: max(count, 0),
+	}
+}
+
+// MatchAnyLines matches zero or more captured lines regardless of content.
+// The shortest run permitting the remaining matchers to succeed is used.
+func MatchAnyLines() LineMatch {
+	return LineMatch{
+		kind:  lineMatchAny,
+		count: -1,
+	}
+}
+
+// MatchUnordered matches the next len(lines) captured lines against lines
+// in any order. It is intended for output produced concurrently where only
+// the content, not the sequence, is deterministic.
+func MatchUnordered(lines ...string) LineMatch {
+	return LineMatch{
+		kind:  lineMatchUnordered,
+		lines: lines,
+	}
+}
+
+type lineMatcher struct {
+	LineMatch
+
+	want []string // Stringified lines used for comparison and display.
+}
+
+type lineMatchState struct {
+	gotRaw  []string
+	gotDisp []string
+	matches []lineMatcher
+	bestGot int
+	bestMch int
+	failed  []bool // Memoized (gotIdx, mchIdx) states known not to match.
+}
+
+func (chk *Chk) literalMatchers(lines ...string) []lineMatcher {
+	matchers := make([]lineMatcher, 0, len(lines))
+
+	for _, line := range chk.prepareSlice(keepLine, lines...) {
+		matchers = append(matchers, lineMatcher{
+			LineMatch: LineMatch{kind: lineMatchLiteral},
+			want:      []string{line},
+		})
+	}
+
+	return matchers
+}
+
+func (chk *Chk) prepareMatchers(want []any) ([]lineMatcher, error) {
+	matchers := make([]lineMatcher, 0, len(want))
+
+	for _, entry := range want {
+		switch v := entry.(type) {
+		case string:
+			matchers = append(matchers, chk.literalMatchers(v)...)
+		case *regexp.Regexp:
+			matchers = append(matchers, lineMatcher{
+				LineMatch: LineMatch{kind: lineMatchRegexp, re: v},
+				want:      []string{"/" + v.String() + "/"},
+			})
+		case LineMatch:
+			matcher := lineMatcher{LineMatch: v}
+
+			switch v.kind {
+			case lineMatchLiteral:
+				matchers = append(matchers, chk.literalMatchers(v.lines...)...)
+
+				continue
+			case lineMatchRegexp:
+				matcher.want = []string{"/" + v.re.String() + "/"}
+			case lineMatchAny:
+				matcher.want = []string{lineMatchAnyLines}
+
+				if v.count >= 0 {
+					matcher.want = make([]string, v.count)
+					for i := range matcher.want {
+						matcher.want[i] = lineMatchAnyLine
+					}
+				}
+			case lineMatchUnordered:
+				for _, line := range v.lines {
+					matcher.want = append(
+						matcher.want, chk.isStringify(line),
+					)
+				}
+			}
+
+			matchers = append(matchers, matcher)
+		default:
+			return nil, fmt.Errorf("%w: %T", ErrInvalidLineMatch, entry)
+		}
+	}
+
+	return matchers, nil
+}
+
+func unorderedMatch(got, want []string) bool {
+	used := make([]bool, len(want))
+
+	for _, gotLine := range got {
+		found := false
+
+		for i, wantLine := range want {
+			if !used[i] && gotLine == wantLine {
+				used[i] = true
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unorderedDisplay aligns the wanted lines of an unordered set with the got
+// lines so that only the lines that could not be paired are highlighted.
+func unorderedDisplay(got, want []string) []string {
+	unmatched := append([]string{}, want...)
+	paired := make([]bool, len(got))
+
+	for i, gotLine := range got {
+		for j, wantLine := range unmatched {
+			if gotLine == wantLine {
+				paired[i] = true
+				unmatched = append(unmatched[:j], unmatched[j+1:]...)
+
+				break
+			}
+		}
+	}
+
+	result := make([]string, 0, len(want))
+
+	for i, gotLine := range got {
+		switch {
+		case paired[i]:
+			result = append(result, gotLine)
+		case len(unmatched) > 0:
+			result = append(result, unmatched[0])
+			unmatched = unmatched[1:]
+		}
+	}
+
+	return append(result, unmatched...)
+}
+
+func (state *lineMatchState) fail(gotIdx, mchIdx int) bool {
+	if mchIdx > state.bestMch ||
+		(mchIdx == state.bestMch && gotIdx > state.bestGot) {
+		//
+		state.bestMch = mchIdx
+		state.bestGot = gotIdx
+	}
+
+	return false
+}
+
+// match reports whether the captured lines from gotIdx on satisfy the
+// matchers from mchIdx on. Failed states are remembered so that the
+// backtracking of MatchAnyLines stays proportional to the number of lines
+// times the number of matchers. Revisiting a failed state cannot improve
+// bestGot or bestMch so skipping it preserves the failure report.
+func (state *lineMatchState) match(gotIdx, mchIdx int) bool {
+	if state.failed == nil {
+		state.failed = make(
+			[]bool, (len(state.gotDisp)+1)*(len(state.matches)+1),
+		)
+	}
+
+	key := gotIdx*(len(state.matches)+1) + mchIdx
+
+	if state.failed[key] {
+		return false
+	}
+
+	if state.try(gotIdx, mchIdx) {
+		return true
+	}
+
+	state.failed[key] = true
+
+	return false
+}
+
+//nolint:cyclop // Ok.
+func (state *lineMatchState) try(gotIdx, mchIdx int) bool {
+	if mchIdx == len(state.matches) {
+		if gotIdx == len(state.gotDisp) {
+			return true
+		}
+
+		return state.fail(gotIdx, mchIdx)
+	}
+
+	matcher := state.matches[mchIdx]
+	remaining := len(state.gotDisp) - gotIdx
+
+	switch matcher.kind {
+	case lineMatchLiteral:
+		if remaining < 1 || state.gotDisp[gotIdx] != matcher.want[0] {
+			return state.fail(gotIdx, mchIdx)
+		}
+
+		return state.match(gotIdx+1, mchIdx+1)
+	case lineMatchRegexp:
+		if remaining < 1 || !matcher.re.MatchString(state.gotRaw[gotIdx]) {
+			return state.fail(gotIdx, mchIdx)
+		}
+
+		return state.match(gotIdx+1, mchIdx+1)
+	case lineMatchAny:
+		if matcher.count >= 0 {
+			if remaining < matcher.count {
+				return state.fail(gotIdx, mchIdx)
+			}
+
+			return state.match(gotIdx+matcher.count, mchIdx+1)
+		}
+
+		for skip := 0; skip <= remaining; skip++ {
+			if state.match(gotIdx+skip, mchIdx+1) {
+				return true
+			}
+		}
+
+		return false
+	case lineMatchUnordered:
+		count := len(matcher.want)
+		if remaining < count ||
+			!unorderedMatch(state.gotDisp[gotIdx:gotIdx+count], matcher.want) {
+			//
+			return state.fail(gotIdx, mchIdx)
+		}
+
+		return state.match(gotIdx+count, mchIdx+1)
+	}
+
+	return state.fail(gotIdx, mchIdx)
+}
+
+// expected builds the want side of the diff: the successfully matched got
+// lines followed by the display form of the matchers that did not match.
+func (state *lineMatchState) expected() []string {
+	result := append([]string{}, state.gotDisp[:state.bestGot]...)
+
+	for i, matcher := range state.matches[state.bestMch:] {
+		if i == 0 && matcher.kind == lineMatchUnordered {
+			end := min(state.bestGot+len(matcher.want), len(state.gotDisp))
+			window := state.gotDisp[state.bestGot:end]
+			result = append(result, unorderedDisplay(window, matcher.want)...)
+
+			continue
+		}
+
+		result = append(result, matcher.want...)
+	}
+
+	return result
+}
+
+func (chk *Chk) compareLogMatch(
+	name, got string,
+	discarded int,
+	gotFilter func(string) string,
+	want ...any,
+) bool {
+	chk.t.Helper()
+
+	matchers, err := chk.prepareMatchers(want)
+	if err != nil {
+		chk.Error(err)
+
+		return false
+	}
+
+	state := &lineMatchState{
+		matches: matchers,
+	}
+
+	if got != "" {
+		got = strings.TrimSuffix(got, "\n")
+
+		for line := range strings.SplitSeq(got, "\n") {
+			line = gotFilter(line)
+			state.gotRaw = append(state.gotRaw, chk.subStr(line))
+			state.gotDisp = append(state.gotDisp, chk.isStringify(line))
+		}
+	}
+
+	if state.match(0, 0) {
+		return true
+	}
+
+	lines := strings.Split(
+		compareSlices(
+			fmt.Sprint("Unexpected ", name, " Entry"),
+			state.gotDisp,
+			state.expected(),
+			settingDiffSlice,
+			settingDiffChars,
+			defaultCmpFunc[string],
+			chk.isStringify,
+		),
+		"\n",
+	)
+	lines = append(lines[:1], windowDiff(lines[1:])...)
+
+	chk.Error(strings.Join(lines, "\n") + truncationNote(discarded))
+
+	return false
+}
+
+// LogMatch compares the internally captured logger output against a list
+// of matchers. Each entry may be a plain string (matching literal lines), a
+// *regexp.Regexp (matching one line) or a LineMatch created by MatchLine,
+// MatchRegexp, MatchAny, MatchAnyLines or MatchUnordered.
+//
+// Log prefixes are stripped exactly as with Log. On failure the region that
+// could not be matched is reported with the standard line diff. Returns true
+// when every captured line is accounted for by the matchers.
+func (chk *Chk) LogMatch(want ...any) bool {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.logSource()
+	if capBuf == nil {
+		chk.Error(
+			"invalid log.Writer check without information being captured",
+		)
+
+		return true
+	}
+
+	chk.markChecked(capBuf)
+
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	got, discarded := capBuf.snapshot()
+
+	return chk.compareLogMatch(name, got, discarded, gotFilter, want...)
+}
+
+// StderrMatch compares the internally captured stderr output against a
+// list of matchers as described for LogMatch. Returns true when every
+// captured line is accounted for by the matchers.
+func (chk *Chk) StderrMatch(want ...any) bool {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.stderrSource()
+	if capBuf == nil {
+		chk.Error("invalid os.Stderr check without information being captured")
+
+		return true
+	}
+
+	chk.markChecked(capBuf)
+
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	got, discarded := capBuf.snapshot()
+
+	return chk.compareLogMatch(name, got, discarded, gotFilter, want...)
+}
+
+// StdoutMatch compares the internally captured stdout output against a
+// list of matchers as described for LogMatch. Returns true when every
+// captured line is accounted for by the matchers.
+func (chk *Chk) StdoutMatch(want ...any) bool {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.stdoutSource()
+	if capBuf == nil {
+		chk.Error("invalid os.Stdout check without information being captured")
+
+		return true
+	}
+
+	chk.markChecked(capBuf)
+
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	got, discarded := capBuf.snapshot()
+
+	return chk.compareLogMatch(name, got, discarded, gotFilter, want...)
+}
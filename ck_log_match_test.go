@@ -0,0 +1,355 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func tstChkLogMatch(t *testing.T) {
+	t.Run("Literal", chkLogMatchTestLiteral)
+	t.Run("Regexp", chkLogMatchTestRegexp)
+	t.Run("Any", chkLogMatchTestAny)
+	t.Run("AnyLines", chkLogMatchTestAnyLines)
+	t.Run("AnyLinesBacktrack", chkLogMatchTestAnyLinesBacktrack)
+	t.Run("Unordered", chkLogMatchTestUnordered)
+	t.Run("Stderr", chkLogMatchTestStderr)
+	t.Run("Log", chkLogMatchTestLog)
+	t.Run("NotCaptured", chkLogMatchTestNotCaptured)
+	t.Run("InvalidMatcher", chkLogMatchTestInvalidMatcher)
+	t.Run("RegexpMismatch", chkLogMatchTestRegexpMismatch)
+	t.Run("UnorderedMismatch", chkLogMatchTestUnorderedMismatch)
+	t.Run("ExtraLines", chkLogMatchTestExtraLines)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestLiteral(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	fmt.Println("line 1")
+	fmt.Println("line 2")
+	fmt.Println("line 3")
+
+	chk.StdoutMatch(
+		"line 1\nline 2",
+		MatchLine("line 3"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestRegexp(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	chk.AddSub(`secret`, "[hidden]")
+
+	fmt.Println("listening on port 8123")
+	fmt.Println("the secret is out")
+
+	chk.StdoutMatch(
+		MatchRegexp(`^listening on port \d+$`),
+		regexp.MustCompile(`^the \[hidden\] is`),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestAny(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	fmt.Println("start")
+	fmt.Println("noise 1")
+	fmt.Println("noise 2")
+	fmt.Println("end")
+
+	chk.StdoutMatch(
+		"start",
+		MatchAny(2),
+		"end",
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestAnyLines(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	fmt.Println("start")
+	fmt.Println("noise 1")
+	fmt.Println("end")
+	fmt.Println("noise 2")
+	fmt.Println("end")
+
+	chk.StdoutMatch(
+		"start",
+		MatchAnyLines(),
+		"end",
+		MatchAnyLines(),
+	)
+
+	chk.StdoutMatch(
+		MatchAnyLines(),
+		"noise 2",
+		"end",
+		MatchAnyLines(),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestAnyLinesBacktrack(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	matchers, err := chk.prepareMatchers([]any{
+		MatchAnyLines(), "x", MatchAnyLines(), "x", MatchAnyLines(), "x",
+		MatchAnyLines(), "x", MatchAnyLines(), "y",
+	})
+	chk.NoErr(err)
+
+	state := &lineMatchState{matches: matchers}
+
+	for range 200 {
+		state.gotRaw = append(state.gotRaw, "x")
+		state.gotDisp = append(state.gotDisp, "x")
+	}
+
+	start := time.Now()
+
+	chk.False(state.match(0, 0))
+	chk.True(time.Since(start) < time.Second)
+
+	chk.Int(state.bestGot, 200)
+	chk.Int(state.bestMch, 9)
+}
+
+func chkLogMatchTestUnordered(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	fmt.Println("start")
+	fmt.Println("worker 3 done")
+	fmt.Println("worker 1 done")
+	fmt.Println("worker 2 done")
+	fmt.Println("end")
+
+	chk.StdoutMatch(
+		"start",
+		MatchUnordered(
+			"worker 1 done",
+			"worker 2 done",
+			"worker 3 done",
+		),
+		"end",
+	)
+}
+
+func chkLogMatchTestStderr(t *testing.T) {
+	chk := CaptureStderr(t)
+	defer chk.Release()
+
+	fmt.Fprintln(os.Stderr, "error 42")
+
+	chk.StderrMatch(MatchRegexp(`^error \d+$`))
+}
+
+func chkLogMatchTestLog(t *testing.T) {
+	chk := CaptureLog(t)
+	defer chk.Release()
+
+	log.SetFlags(log.LstdFlags)
+	log.Print("b")
+	log.Print("a")
+
+	chk.LogMatch(MatchUnordered("a", "b"))
+}
+
+func chkLogMatchTestNotCaptured(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.StdoutMatch()
+
+	chk.StderrMatch()
+
+	chk.LogMatch()
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("StdoutMatch"),
+		chkOutError(
+			"invalid os.Stdout check without information being captured",
+		),
+		chkOutHelper("StderrMatch"),
+		chkOutError(
+			"invalid os.Stderr check without information being captured",
+		),
+		chkOutHelper("LogMatch"),
+		chkOutError(
+			"invalid log.Writer check without information being captured",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkLogMatchTestInvalidMatcher(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.StdoutMatch(42)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("StdoutMatch"),
+		chkOutHelper("compareLogMatch"),
+		chkOutError("invalid line matcher: int"),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestRegexpMismatch(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	fmt.Println("start")
+	fmt.Println("listening on port abc")
+
+	chk.StdoutMatch(
+		"start",
+		MatchRegexp(`^listening on port \d+$`),
+	)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("StdoutMatch"),
+		chkOutHelper("compareLogMatch"),
+		chkOutHelper("Error"),
+		"Error: (*Chk).Error",
+		"Unexpected stdout Entry: got (2 lines) - want (2 lines)",
+		"0:0 start",
+		chkOutLnChanged(
+			"1", "1",
+			markAsDel("/^")+"listening on port "+
+				markAsChg("abc", `\d+$/`, diffMerge),
+		),
+		"Fail Now: (*Chk).Error",
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestUnorderedMismatch(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	fmt.Println("worker 3 done")
+	fmt.Println("worker 1 failed")
+	fmt.Println("worker 2 done")
+
+	chk.StdoutMatch(
+		MatchUnordered(
+			"worker 1 done",
+			"worker 2 done",
+			"worker 3 done",
+		),
+	)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("StdoutMatch"),
+		chkOutHelper("compareLogMatch"),
+		chkOutHelper("Error"),
+		"Error: (*Chk).Error",
+		"Unexpected stdout Entry: got (3 lines) - want (3 lines)",
+		"0:0 worker 3 done",
+		chkOutLnChanged(
+			"1", "1",
+			"worker 1 "+markAsChg("failed", "done", diffMerge),
+		),
+		"2:2 worker 2 done",
+		"Fail Now: (*Chk).Error",
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogMatchTestExtraLines(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	fmt.Println("start")
+	fmt.Println("unexpected")
+
+	chk.StdoutMatch(
+		"start",
+	)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("StdoutMatch"),
+		chkOutHelper("compareLogMatch"),
+		chkOutHelper("Error"),
+		"Error: (*Chk).Error",
+		"Unexpected stdout Entry: got (2 lines) - want (1 lines)",
+		"0:0 start",
+		chkOutLnGot("1", "unexpected"),
+		"Fail Now: (*Chk).Error",
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
@@ -73,6 +73,16 @@ func tstChkLogging(t *testing.T) {
 	t.Run("LeadingAndTrainingSpaces",
 		chkLogTestLeadingAndTrainingSpaces)
 	t.Run("Slog", chkLogTestSlog)
+	t.Run("NextStdout", chkLogTestNextStdout)
+	t.Run("NextStderr", chkLogTestNextStderr)
+	t.Run("NextLog", chkLogTestNextLog)
+	t.Run("NextLogWithStderr", chkLogTestNextLogWithStderr)
+	t.Run("NextNotCaptured", chkLogTestNextNotCaptured)
+	t.Run("NextMismatch", chkLogTestNextMismatch)
+	t.Run("ResetCapture", chkLogTestResetCapture)
+	t.Run("CaptureMax", chkLogTestCaptureMax)
+	t.Run("CaptureMaxTruncated", chkLogTestCaptureMaxTruncated)
+	t.Run("WindowDiff", chkLogTestWindowDiff)
 }
 
 //nolint:cyclop,funlen,gocognit // Ok.
@@ -1067,3 +1077,285 @@ func chkLogTestSlog(t *testing.T) {
 		chkOutPush("Pre", "func1"),
 	)
 }
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogTestNextStdout(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	fmt.Println("step 1 line 1")
+	fmt.Println("step 1 line 2")
+
+	chk.StdoutNext(
+		"step 1 line 1",
+		"step 1 line 2",
+	)
+
+	chk.StdoutNext()
+
+	fmt.Println("step 2 line 1")
+
+	chk.StdoutNext("step 2 line 1")
+
+	fmt.Println("step 3 line 1")
+
+	chk.Stdout("step 3 line 1")
+	chk.Stdout("step 3 line 1")
+}
+
+func chkLogTestNextStderr(t *testing.T) {
+	chk := CaptureStderr(t)
+	defer chk.Release()
+
+	fmt.Fprintln(os.Stderr, "step 1")
+
+	chk.StderrNext("step 1")
+
+	fmt.Fprintln(os.Stderr, "step 2")
+
+	chk.StderrNext("step 2")
+	chk.Stderr()
+}
+
+func chkLogTestNextLog(t *testing.T) {
+	chk := CaptureLog(t)
+	defer chk.Release()
+
+	log.Print("step 1")
+
+	chk.LogNext("step 1")
+
+	log.Print("step 2")
+
+	chk.LogNext("step 2")
+	chk.Log()
+}
+
+func chkLogTestNextLogWithStderr(t *testing.T) {
+	chk := CaptureLogWithStderr(t)
+	defer chk.Release()
+
+	log.Print("step 1")
+	fmt.Fprintln(os.Stderr, "step 1 stderr")
+
+	chk.LogNext(
+		"step 1",
+		"step 1 stderr",
+	)
+
+	fmt.Fprintln(os.Stderr, "step 2 stderr")
+
+	chk.StderrNext("step 2 stderr")
+	chk.Log()
+}
+
+func chkLogTestNextNotCaptured(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.StdoutNext()
+
+	chk.StderrNext()
+
+	chk.LogNext()
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("StdoutNext"),
+		chkOutError(
+			"invalid os.Stdout check without information being captured",
+		),
+		chkOutHelper("StderrNext"),
+		chkOutError(
+			"invalid os.Stderr check without information being captured",
+		),
+		chkOutHelper("LogNext"),
+		chkOutError(
+			"invalid log.Writer check without information being captured",
+		),
+		chkOutRelease(),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogTestNextMismatch(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	fmt.Println("step 1")
+
+	chk.StdoutNext("step 1")
+
+	fmt.Println("step 2")
+
+	chk.StdoutNext()
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("StdoutNext"),
+		chkOutHelper("compareLog"),
+		chkOutHelper("StdoutNext"),
+		chkOutHelper("compareLog"),
+		chkOutHelper("Error"),
+		"Error: (*Chk).Error",
+		"Unexpected stdout Entry: got (1 lines) - want (0 lines)",
+		chkOutLnGot("0", "step 2"),
+		"Fail Now: (*Chk).Error",
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogTestResetCapture(t *testing.T) {
+	chk := CaptureLogAndStderrAndStdout(t)
+	defer chk.Release()
+
+	fmt.Println("discarded stdout")
+	fmt.Fprintln(os.Stderr, "discarded stderr")
+	log.Print("discarded log")
+
+	chk.ResetCapture()
+
+	fmt.Println("kept stdout")
+	fmt.Fprintln(os.Stderr, "kept stderr")
+	log.Print("kept log")
+
+	chk.Stdout("kept stdout")
+	chk.Stderr("kept stderr")
+	chk.Log("kept log")
+}
+
+func captureMaxLine(i int) string {
+	return fmt.Sprintf("line %d %s", i, strings.Repeat(".", 92))
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogTestCaptureMax(t *testing.T) {
+	defer func(orig int) {
+		settingCaptureMax = orig
+	}(settingCaptureMax)
+
+	settingCaptureMax = 1000
+
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	wantLines := make([]string, 10)
+	for i := range wantLines {
+		wantLines[i] = captureMaxLine(i)
+		fmt.Println(wantLines[i])
+	}
+
+	fmt.Println("discarded")
+
+	chk.Stdout(wantLines...)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogTestCaptureMaxTruncated(t *testing.T) {
+	defer func(orig int) {
+		settingCaptureMax = orig
+	}(settingCaptureMax)
+
+	settingCaptureMax = 1000
+
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	wantLines := make([]string, 10)
+	for i := range wantLines {
+		wantLines[i] = captureMaxLine(i)
+		fmt.Println(wantLines[i])
+	}
+
+	fmt.Println("discarded")
+
+	wantLines[9] = "line 9"
+
+	chk.Stdout(wantLines...)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutHelper("Error"),
+		"Error: (*Chk).Error",
+		"Unexpected stdout Entry: got (10 lines) - want (10 lines)",
+		"... 6 unchanged lines ...",
+		chkOutLnSame("06", "06", captureMaxLine(6)),
+		chkOutLnSame("07", "07", captureMaxLine(7)),
+		chkOutLnSame("08", "08", captureMaxLine(8)),
+		chkOutLnChanged("09", "09",
+			"line 9"+markAsIns(" "+strings.Repeat(".", 92)),
+		),
+		"capture truncated: 10 bytes discarded after reaching the limit"+
+			" of 1000 bytes",
+		"Fail Now: (*Chk).Error",
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+func chkLogTestWindowDiff(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	same := func(i int) string {
+		return fmt.Sprint(i, ":", i, " same")
+	}
+
+	changed := markAsChg("a", "b", diffMerge)
+
+	diffLines := make([]string, 0, 20)
+	for i := range 10 {
+		diffLines = append(diffLines, same(i))
+	}
+
+	diffLines = append(diffLines, changed)
+
+	for i := 11; i < 13; i++ {
+		diffLines = append(diffLines, same(i))
+	}
+
+	diffLines = append(diffLines, changed)
+
+	for i := 14; i < 20; i++ {
+		diffLines = append(diffLines, same(i))
+	}
+
+	chk.StrSlice(
+		windowDiff(diffLines),
+		[]string{
+			"... 7 unchanged lines ...",
+			same(7), same(8), same(9),
+			changed,
+			same(11), same(12),
+			changed,
+			same(14), same(15), same(16),
+			"... 3 unchanged lines ...",
+		},
+	)
+
+	chk.StrSlice(windowDiff([]string{same(0), changed}), []string{
+		same(0), changed,
+	})
+}
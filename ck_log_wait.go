@@ -0,0 +1,162 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// waitForLine watches capBuf until a complete line (after filtering and
+// substitutions) matches re or the timeout expires. It returns the matching
+// line, whether a match was found and the lines seen.
+func (chk *Chk) waitForLine(
+	capBuf *captureBuf,
+	gotFilter func(string) string,
+	re *regexp.Regexp,
+	timeout time.Duration,
+) (string, bool, []string) {
+	var seen []string
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		data, changed := capBuf.watch()
+
+		// Only complete lines are considered so a line still being written
+		// cannot match prematurely.
+		complete := data[:strings.LastIndex(data, "\n")+1]
+		seen = seen[:0]
+
+		if complete != "" {
+			complete = strings.TrimSuffix(complete, "\n")
+
+			for line := range strings.SplitSeq(complete, "\n") {
+				line = chk.subStr(gotFilter(line))
+				seen = append(seen, line)
+
+				if re.MatchString(line) {
+					return line, true, seen
+				}
+			}
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return "", false, seen
+		}
+	}
+}
+
+func (chk *Chk) waitFor(
+	name string,
+	capBuf *captureBuf,
+	gotFilter func(string) string,
+	pattern string,
+	timeout time.Duration,
+) string {
+	chk.t.Helper()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		chk.Error(err)
+
+		return ""
+	}
+
+	line, found, seen := chk.waitForLine(capBuf, gotFilter, re, timeout)
+	if !found {
+		chk.Error(
+			errMsgHeaderf(
+				name,
+				"timeout after %v waiting for line matching /%s/",
+				timeout, pattern,
+			) +
+				fmt.Sprint("output seen so far (", len(seen), " lines) [\n") +
+				strings.Join(seen, "\n") + "\n]",
+		)
+	}
+
+	return line
+}
+
+// WaitForLog blocks until a line of captured logger output matches the
+// regular expression pattern, or until timeout expires. Log prefixes are
+// removed and substitutions applied before matching.
+//
+// The matching line is returned. On timeout the failure, including all
+// logger output seen so far, is reported and an empty string is returned.
+// Waiting does not count as a check so the logger output must still be
+// verified before chk.Release().
+func (chk *Chk) WaitForLog(pattern string, timeout time.Duration) string {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.logSource()
+	if capBuf == nil {
+		chk.Error(
+			"invalid log.Writer wait without information being captured",
+		)
+
+		return ""
+	}
+
+	return chk.waitFor(name, capBuf, gotFilter, pattern, timeout)
+}
+
+// WaitForStderr blocks until a line of captured stderr output matches the
+// regular expression pattern, or until timeout expires. It is useful for
+// synchronizing with a server started by the test. The behavior is
+// otherwise identical to WaitForLog.
+func (chk *Chk) WaitForStderr(pattern string, timeout time.Duration) string {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.stderrSource()
+	if capBuf == nil {
+		chk.Error("invalid os.Stderr wait without information being captured")
+
+		return ""
+	}
+
+	return chk.waitFor(name, capBuf, gotFilter, pattern, timeout)
+}
+
+// WaitForStdout blocks until a line of captured stdout output matches the
+// regular expression pattern, or until timeout expires. It is useful for
+// synchronizing with a server started by the test, e.g.:
+//
+//	go runServer()
+//	chk.WaitForStdout(`^listening on `, time.Second)
+//
+// The behavior is otherwise identical to WaitForLog.
+func (chk *Chk) WaitForStdout(pattern string, timeout time.Duration) string {
+	chk.t.Helper()
+
+	name, capBuf, gotFilter := chk.stdoutSource()
+	if capBuf == nil {
+		chk.Error("invalid os.Stdout wait without information being captured")
+
+		return ""
+	}
+
+	return chk.waitFor(name, capBuf, gotFilter, pattern, timeout)
+}
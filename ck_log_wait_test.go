@@ -0,0 +1,211 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+func tstChkLogWait(t *testing.T) {
+	t.Run("Stdout", chkLogWaitTestStdout)
+	t.Run("Stderr", chkLogWaitTestStderr)
+	t.Run("Log", chkLogWaitTestLog)
+	t.Run("PartialLine", chkLogWaitTestPartialLine)
+	t.Run("NotCaptured", chkLogWaitTestNotCaptured)
+	t.Run("InvalidPattern", chkLogWaitTestInvalidPattern)
+	t.Run("Timeout", chkLogWaitTestTimeout)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogWaitTestStdout(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		fmt.Println("starting")
+		time.Sleep(time.Millisecond * 20)
+		fmt.Println("listening on :8080")
+	}()
+
+	chk.Str(
+		chk.WaitForStdout(`^listening on `, time.Second),
+		"listening on :8080",
+	)
+
+	<-done
+
+	chk.Stdout(
+		"starting",
+		"listening on :8080",
+	)
+}
+
+func chkLogWaitTestStderr(t *testing.T) {
+	chk := CaptureStderr(t)
+	defer chk.Release()
+
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		fmt.Fprintln(os.Stderr, "ready")
+	}()
+
+	chk.Str(chk.WaitForStderr(`^ready$`, time.Second), "ready")
+
+	chk.Stderr("ready")
+}
+
+func chkLogWaitTestLog(t *testing.T) {
+	chk := CaptureLog(t)
+	defer chk.Release()
+
+	chk.AddSub(`\d+`, "#")
+	log.SetFlags(log.LstdFlags)
+
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		log.Print("connected to port 1234")
+	}()
+
+	chk.Str(
+		chk.WaitForLog(`^connected to port #$`, time.Second),
+		"connected to port #",
+	)
+
+	chk.Log("connected to port #")
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogWaitTestPartialLine(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	go func() {
+		fmt.Print("listening")
+		time.Sleep(time.Millisecond * 20)
+		fmt.Println(" on :8080")
+	}()
+
+	chk.Str(
+		chk.WaitForStdout(`^listening`, time.Second),
+		"listening on :8080",
+	)
+
+	chk.Stdout("listening on :8080")
+}
+
+func chkLogWaitTestNotCaptured(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.WaitForStdout(`.`, time.Millisecond)
+
+	chk.WaitForStderr(`.`, time.Millisecond)
+
+	chk.WaitForLog(`.`, time.Millisecond)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("WaitForStdout"),
+		chkOutError(
+			"invalid os.Stdout wait without information being captured",
+		),
+		chkOutHelper("WaitForStderr"),
+		chkOutError(
+			"invalid os.Stderr wait without information being captured",
+		),
+		chkOutHelper("WaitForLog"),
+		chkOutError(
+			"invalid log.Writer wait without information being captured",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkLogWaitTestInvalidPattern(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.Stdout()
+
+	chk.WaitForStdout(`(`, time.Millisecond)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutHelper("WaitForStdout"),
+		chkOutHelper("waitFor"),
+		chkOutError(
+			"error parsing regexp: missing closing ): `(`",
+		),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkLogWaitTestTimeout(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	fmt.Println("starting")
+	fmt.Println("still starting")
+
+	chk.Stdout("starting", "still starting")
+
+	chk.WaitForStdout(`^listening`, time.Millisecond*20)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutHelper("WaitForStdout"),
+		chkOutHelper("waitFor"),
+		chkOutError(
+			chkOutCommonMsg(
+				"timeout after 20ms waiting for line matching /^listening/",
+				"stdout",
+			),
+			"output seen so far (2 lines) [",
+			"starting",
+			"still starting",
+			"]",
+		),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
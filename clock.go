@@ -22,6 +22,7 @@ import (
 	"log"
 	"math"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -113,47 +114,47 @@ func (chk *Chk) ClockSetCusC(f string) {
 
 // ClockLast returns the most recent timestamp generated by the test clock.
 func (chk *Chk) ClockLast() time.Time {
-	return chk.clk.lastTS
+	return chk.clk.last()
 }
 
 // ClockLastFmtTime returns the most recent clock value formatted as HHmmSS.
 func (chk *Chk) ClockLastFmtTime() string {
-	return chk.clk.lastTS.Format(clkSubTime)
+	return chk.clk.last().Format(clkSubTime)
 }
 
 // ClockLastFmtDate returns the most recent clock value formatted as YYYYMMDD.
 func (chk *Chk) ClockLastFmtDate() string {
-	return chk.clk.lastTS.Format(clkSubDate)
+	return chk.clk.last().Format(clkSubDate)
 }
 
 // ClockLastFmtTS returns the most recent clock value formatted as
 // YYYYMMDDHHmmSS.
 func (chk *Chk) ClockLastFmtTS() string {
-	return chk.clk.lastTS.Format(clkSubTS)
+	return chk.clk.last().Format(clkSubTS)
 }
 
 // ClockLastFmtNano returns the most recent clock value formatted as
 // YYYYMMDDHHmmSS.#########.
 func (chk *Chk) ClockLastFmtNano() string {
-	return chk.clk.lastTS.Format(clkSubNano)
+	return chk.clk.last().Format(clkSubNano)
 }
 
 // ClockLastFmtCusA returns the most recent clock value using the custom A
 // format.
 func (chk *Chk) ClockLastFmtCusA() string {
-	return chk.clk.lastTS.Format(chk.clkCusA)
+	return chk.clk.last().Format(chk.clkCusA)
 }
 
 // ClockLastFmtCusB returns the most recent clock value using the custom B
 // format.
 func (chk *Chk) ClockLastFmtCusB() string {
-	return chk.clk.lastTS.Format(chk.clkCusB)
+	return chk.clk.last().Format(chk.clkCusB)
 }
 
 // ClockLastFmtCusC returns the most recent clock value using the custom C
 // format.
 func (chk *Chk) ClockLastFmtCusC() string {
-	return chk.clk.lastTS.Format(chk.clkCusC)
+	return chk.clk.last().Format(chk.clkCusC)
 }
 
 // ClockNext advances the test clock by one increment and returns the result
@@ -263,9 +264,12 @@ func (chk *Chk) ClockSet(newTime time.Time, inc ...time.Duration) func() {
 	}
 
 	chk.clk = newTstClock(newTime, inc)
+	savedClk.wake()
 
 	return func() {
+		replacedClk := chk.clk
 		chk.clk = savedClk
+		replacedClk.wake()
 	}
 }
 
@@ -277,7 +281,7 @@ func (chk *Chk) ClockSet(newTime time.Time, inc ...time.Duration) func() {
 // intended for use with defer. Internally, the last time recorded is
 // initialized to newTime minus the final increment.
 func (chk *Chk) ClockOffsetDay(dayOffset int, inc ...time.Duration) func() {
-	t := chk.clk.lastTS
+	t := chk.clk.last()
 
 	return chk.ClockSet(t.AddDate(0, 0, dayOffset), inc...)
 }
@@ -290,16 +294,20 @@ func (chk *Chk) ClockOffsetDay(dayOffset int, inc ...time.Duration) func() {
 // intended for use with defer. Internally, the last time recorded is
 // initialized to newTime minus the final increment.
 func (chk *Chk) ClockOffset(d time.Duration, inc ...time.Duration) func() {
-	t := chk.clk.nextTS
+	t := chk.clk.peek()
 
 	return chk.ClockSet(t.Add(d), inc...)
 }
 
+// tstClk is the deterministic test clock. It is guarded by mu as fakes
+// paced with the clock may advance it from any goroutine.
 type tstClk struct {
+	mu        sync.Mutex
 	lastTS    time.Time
 	nextTS    time.Time
 	inc       []time.Duration
 	nextIndex int
+	notify    chan struct{}
 }
 
 func newTstClock(startAt time.Time, inc []time.Duration) *tstClk {
@@ -316,6 +324,9 @@ func newTstClock(startAt time.Time, inc []time.Duration) *tstClk {
 }
 
 func (clk *tstClk) next() time.Time {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+
 	clk.lastTS = clk.nextTS
 	if clk.nextIndex >= len(clk.inc) {
 		clk.nextIndex = 0
@@ -323,6 +334,53 @@ func (clk *tstClk) next() time.Time {
 
 	clk.nextTS = clk.nextTS.Add(clk.inc[clk.nextIndex])
 	clk.nextIndex++
+	clk.signal()
+
+	return clk.lastTS
+}
+
+// last returns the most recent timestamp generated.
+func (clk *tstClk) last() time.Time {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
 
 	return clk.lastTS
 }
+
+// peek returns the timestamp the next tick will generate without
+// generating it.
+func (clk *tstClk) peek() time.Time {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+
+	return clk.nextTS
+}
+
+// watch returns a channel closed the next time the clock moves or is
+// replaced, letting fake connections re-evaluate their deadlines.
+func (clk *tstClk) watch() <-chan struct{} {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+
+	if clk.notify == nil {
+		clk.notify = make(chan struct{})
+	}
+
+	return clk.notify
+}
+
+// wake releases any watcher of the clock.
+func (clk *tstClk) wake() {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+
+	clk.signal()
+}
+
+// signal releases any watcher. The caller must hold the lock.
+func (clk *tstClk) signal() {
+	if clk.notify != nil {
+		close(clk.notify)
+		clk.notify = nil
+	}
+}
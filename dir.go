@@ -93,6 +93,18 @@ func (chk *Chk) SetTmpDir(dir string) string {
 	return lastTmpDir
 }
 
+// SetTmpIsolate turns the isolation of temporary directories on or off,
+// returning the previous value. The setting applies only to the current
+// test and must be made before the temporary directory is first created.
+// See CreateTmpDir. If not explicitly set, the default is taken from the
+// SZTEST_TMP_ISOLATE environment variable or falls back to false.
+func (chk *Chk) SetTmpIsolate(isolate bool) bool {
+	lastIsolate := settingTmpIsolate
+	settingTmpIsolate = isolate
+
+	return lastIsolate
+}
+
 func removeTestDir(dir string) error {
 	fi, err := os.Stat(dir)
 	if errors.Is(err, os.ErrNotExist) {
@@ -173,7 +185,7 @@ func (chk *Chk) createFile(
 		err = os.WriteFile(path, data, perm)
 		if err == nil {
 			chk.PushPreReleaseFunc(func() error {
-				if chk.faultCount == 0 && !chk.keepTmpFiles {
+				if chk.removeTmpFiles() {
 					return removeTestFile(path)
 				}
 
@@ -353,32 +365,71 @@ func (chk *Chk) CreateTmpSubDir(subDirs ...string) string {
 	return fullPath
 }
 
+// tmpDirRoot returns the root temporary directory once created or the
+// un-isolated name it would be given otherwise.
+func (chk *Chk) tmpDirRoot() string {
+	if chk.tmpDirPath != "" {
+		return chk.tmpDirPath
+	}
+
+	return filepath.Join(settingTmpDir, chk.Name())
+}
+
 // CreateTmpDir creates the root temporary directory for the current test,
 // named after the test function and placed under the configured root
 // (defaulting to SZTEST_TMP_DIR or /tmp). If the directory already exists,
 // it is left unchanged and the absolute path is returned. Unless
 // KeepTmpFiles is called, the directory and its contents are automatically
-// removed when the test finishes without errors.
+// removed when the test finishes without errors (see SZTEST_KEEP_TMP). A
+// retained directory is logged along with a listing of its contents.
+//
+// When isolation is on (see SetTmpIsolate) a unique "@<id>" suffix is added
+// to the name so that repeated, parallel or concurrent runs of the same
+// test never share a directory, and a "<name>@latest" symbolic link is
+// pointed at the newest one to ease debugging. If SZTEST_TMP_PRUNE is set,
+// isolated directories of the same test older than that age are removed
+// first.
 func (chk *Chk) CreateTmpDir() string {
 	var err error
 
-	path := filepath.Join(settingTmpDir, chk.Name())
+	path := chk.tmpDirRoot()
 
 	if !chk.tmpDirCreated { //nolint:nestif // Ok.
 		chk.t.Helper()
 
-		err = removeTestDir(path)
-		if err == nil {
-			err = os.Mkdir(path, settingPermDir)
+		isolate := settingTmpIsolate
+
+		if isolate {
+			var isolatedPath string
+
+			isolatedPath, err = createIsolatedTmpDir(path)
+			if err == nil {
+				path = isolatedPath
+			}
+		} else {
+			err = removeTestDir(path)
 			if err == nil {
-				chk.PushPreReleaseFunc(func() error {
-					if chk.faultCount == 0 && !chk.keepTmpFiles {
-						return removeTestDir(path)
+				err = os.Mkdir(path, settingPermDir)
+			}
+		}
+
+		if err == nil {
+			chk.tmpDirPath = path
+			chk.PushPreReleaseFunc(func() error {
+				if chk.removeTmpFiles() {
+					err := removeTestDir(path)
+					if err == nil && isolate {
+						err = removeLatestTmpLink(path)
 					}
 
-					return nil
-				})
-			}
+					return err
+				}
+
+				chk.t.Helper()
+				chk.Logf("%s", tmpSummary(path))
+
+				return nil
+			})
 		}
 	}
 
@@ -0,0 +1,35 @@
+//go:build unix
+
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+	"syscall"
+)
+
+func mkfifo(path string, perm os.FileMode) error {
+	err := syscall.Mkfifo(path, uint32(perm.Perm()))
+	if err != nil {
+		return &os.PathError{Op: "mkfifo", Path: path, Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	tmpIsolateSep    = "@"
+	tmpIsolateLatest = "latest"
+)
+
+func isIsolatedTmpID(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pruneIsolatedTmpDirs removes the isolated directories of base (see
+// createIsolatedTmpDir) not modified within the last maxAge.
+func pruneIsolatedTmpDirs(base string, maxAge time.Duration) error {
+	entries, err := os.ReadDir(filepath.Dir(base))
+	if err != nil {
+		return err //nolint:wrapcheck // Ok.
+	}
+
+	prefix := filepath.Base(base) + tmpIsolateSep
+	cutoff := time.Now().Add(-maxAge)
+
+	for _, entry := range entries {
+		id, found := strings.CutPrefix(entry.Name(), prefix)
+		if !found || !isIsolatedTmpID(id) || !entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // Removed by someone else.
+		}
+
+		if err == nil && info.ModTime().Before(cutoff) {
+			err = removeTestDir(filepath.Join(filepath.Dir(base), entry.Name()))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createIsolatedTmpDir creates a new directory named base@<id> with a
+// unique numeric id, pointing the base@latest symbolic link to it.
+func createIsolatedTmpDir(base string) (string, error) {
+	var err error
+
+	if settingTmpPrune > 0 {
+		err = pruneIsolatedTmpDirs(base, settingTmpPrune)
+	}
+
+	path := ""
+
+	if err == nil {
+		path, err = os.MkdirTemp(
+			filepath.Dir(base), filepath.Base(base)+tmpIsolateSep+"*",
+		)
+	}
+
+	if err == nil {
+		err = os.Chmod(path, settingPermDir)
+	}
+
+	if err == nil {
+		// Replaced atomically as other runs may be updating it too.
+		tmpLink := path + tmpIsolateSep + tmpIsolateLatest
+
+		err = os.Symlink(filepath.Base(path), tmpLink)
+		if err == nil {
+			err = os.Rename(tmpLink, latestTmpLink(base))
+			if err != nil {
+				_ = os.Remove(tmpLink)
+			}
+		}
+	}
+
+	if err != nil {
+		if path != "" {
+			_ = os.RemoveAll(path)
+		}
+
+		return "", err //nolint:wrapcheck // Ok.
+	}
+
+	return path, nil
+}
+
+func latestTmpLink(base string) string {
+	return base + tmpIsolateSep + tmpIsolateLatest
+}
+
+// removeLatestTmpLink removes the base@latest symbolic link if it still
+// refers to the isolated directory path.
+func removeLatestTmpLink(path string) error {
+	link := latestTmpLink(path[:strings.LastIndex(path, tmpIsolateSep)])
+
+	target, err := os.Readlink(link)
+	if errors.Is(err, fs.ErrNotExist) ||
+		(err == nil && target != filepath.Base(path)) {
+		return nil
+	}
+
+	if err == nil {
+		err = os.Remove(link)
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
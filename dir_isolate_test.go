@@ -0,0 +1,178 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func tstChkDirIsolate(t *testing.T) {
+	t.Run("Isolated", chkDirIsolateTestIsolated)
+	t.Run("NotIsolated", chkDirIsolateTestNotIsolated)
+	t.Run("Prune", chkDirIsolateTestPrune)
+	t.Run("KeepOnFailure", chkDirIsolateTestKeepOnFailure)
+}
+
+func chkDirIsolateTestIsolated(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetTmpIsolate(chk.SetTmpIsolate(true))
+
+	base := filepath.Join(settingTmpDir, chk.Name())
+	latest := base + "@latest"
+
+	first := CaptureNothing(t)
+	second := CaptureNothing(t)
+
+	firstDir := first.CreateTmpDir()
+	chk.Str(first.CreateTmpDir(), firstDir)
+	chk.True(strings.HasPrefix(firstDir, base+"@"))
+	chk.True(isIsolatedTmpID(strings.TrimPrefix(firstDir, base+"@")))
+
+	target, err := os.Readlink(latest)
+	chk.NoErr(err)
+	chk.Str(target, filepath.Base(firstDir))
+
+	secondDir := second.CreateTmpDir()
+	chk.True(secondDir != firstDir)
+	chk.True(strings.HasPrefix(secondDir, base+"@"))
+
+	target, err = os.Readlink(latest)
+	chk.NoErr(err)
+	chk.Str(target, filepath.Base(secondDir))
+
+	// Files of the same name do not collide.
+	firstFile := first.CreateTmpFileAs("", "data.txt", []byte("first\n"))
+	secondFile := second.CreateTmpFileAs("", "data.txt", []byte("second\n"))
+	chk.FileContents(firstFile, "first")
+	chk.FileContents(secondFile, "second")
+
+	// Latest link is left alone as it refers to the second directory.
+	first.Release()
+	chk.FileNotExists(firstDir)
+	chk.FileExists(latest)
+
+	second.Release()
+	chk.FileNotExists(secondDir)
+	chk.FileNotExists(latest)
+	chk.FileNotExists(base)
+}
+
+func chkDirIsolateTestNotIsolated(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetTmpIsolate(chk.SetTmpIsolate(false))
+
+	chk.Str(chk.CreateTmpDir(), filepath.Join(settingTmpDir, chk.Name()))
+	chk.FileNotExists(filepath.Join(settingTmpDir, chk.Name()+"@latest"))
+}
+
+func chkDirIsolateTestPrune(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetTmpIsolate(chk.SetTmpIsolate(true))
+
+	origPrune := settingTmpPrune
+	settingTmpPrune = time.Hour
+
+	defer func() {
+		settingTmpPrune = origPrune
+	}()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	base := filepath.Join(settingTmpDir, iChk.Name())
+	stale := base + "@111"
+	recent := base + "@222"
+	other := base + "@other"
+	old := time.Now().Add(-2 * time.Hour)
+
+	for _, dir := range []string{stale, recent, other} {
+		chk.NoErr(os.MkdirAll(filepath.Join(dir, "sub"), settingPermDir))
+
+		defer func() {
+			chk.NoErr(os.RemoveAll(dir))
+		}()
+	}
+
+	chk.NoErr(os.Chtimes(stale, old, old))
+	chk.NoErr(os.Chtimes(other, old, old))
+
+	dir := iChk.CreateTmpDir()
+
+	chk.FileNotExists(stale)
+	chk.FileExists(recent)
+	chk.FileExists(other)
+	chk.FileExists(dir)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+
+	chk.FileNotExists(dir)
+}
+
+func chkDirIsolateTestKeepOnFailure(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetTmpIsolate(chk.SetTmpIsolate(true))
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	dir := iChk.CreateTmpDir()
+	latest := filepath.Join(settingTmpDir, iChk.Name()+"@latest")
+
+	defer func() {
+		chk.NoErr(os.RemoveAll(dir))
+		chk.NoErr(os.Remove(latest))
+	}()
+
+	iChk.Error("forced failure")
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutError("forced failure"),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutTmpSummary(dir),
+	)
+
+	chk.FileExists(dir)
+	chk.Symlink(latest, filepath.Base(dir))
+}
@@ -0,0 +1,67 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"strings"
+)
+
+// Values of the SZTEST_KEEP_TMP setting.
+const (
+	keepTmpNever     = "never"
+	keepTmpOnFailure = "on-failure"
+	keepTmpAlways    = "always"
+)
+
+// removeTmpFiles reports whether the temporary files created by the test
+// are to be removed on release. Files are always kept if KeepTmpFiles was
+// called, otherwise as directed by the SZTEST_KEEP_TMP setting.
+func (chk *Chk) removeTmpFiles() bool {
+	if chk.keepTmpFiles {
+		return false
+	}
+
+	switch settingKeepTmp {
+	case keepTmpNever:
+		return true
+	case keepTmpAlways:
+		return false
+	default:
+		return chk.faultCount == 0
+	}
+}
+
+// tmpSummary describes the retained temporary directory path listing
+// everything left in it.
+func tmpSummary(path string) string {
+	var summary strings.Builder
+
+	summary.WriteString("temporary files retained in: " + path)
+
+	entries, err := walkDirTree(path)
+	if err != nil {
+		summary.WriteString("\n  could not list files: " + err.Error())
+	}
+
+	for _, entry := range entries {
+		summary.WriteString("\n  " + entry.String())
+	}
+
+	return summary.String()
+}
@@ -0,0 +1,148 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func chkOutTmpSummary(path string, entries ...string) string {
+	lines := append([]string{"temporary files retained in: " + path}, entries...)
+
+	return "" +
+		chkOutHelper("CreateTmpDir.func1") +
+		chkOutLogf(strings.Join(lines, "\n  "))
+}
+
+func tstChkDirKeep(t *testing.T) {
+	t.Run("Never", chkDirKeepTestNever)
+	t.Run("Always", chkDirKeepTestAlways)
+	t.Run("KeepTmpFiles", chkDirKeepTestKeepTmpFiles)
+}
+
+func setKeepTmp(keep string) func() {
+	origKeep := settingKeepTmp
+	settingKeepTmp = keep
+
+	return func() {
+		settingKeepTmp = origKeep
+	}
+}
+
+func chkDirKeepTestNever(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer setKeepTmp(keepTmpNever)()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	dir := iChk.CreateTmpSubDir("sub")
+
+	iChk.Error("forced failure")
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutError("forced failure"),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+
+	chk.FileNotExists(dir)
+	chk.FileNotExists(iChk.tmpDirRoot())
+}
+
+func chkDirKeepTestAlways(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer setKeepTmp(keepTmpAlways)()
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	tmpDir := iChk.CreateTmpDir()
+
+	defer func() {
+		chk.NoErr(os.RemoveAll(tmpDir))
+	}()
+
+	iChk.CreateTmpFileAs(iChk.CreateTmpSubDir("sub"), "data.txt", nil)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("CreateTmpFileAs"),
+		chkOutHelper("createFile"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutPush("Pre", "func1"),
+		chkOutTmpSummary(tmpDir,
+			"drwx------ sub/",
+			"-rw------- sub/data.txt",
+		),
+	)
+
+	chk.FileContents(filepath.Join(tmpDir, "sub", "data.txt"))
+}
+
+func chkDirKeepTestKeepTmpFiles(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer setKeepTmp(keepTmpNever)()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.KeepTmpFiles()
+
+	tmpDir := iChk.CreateTmpDir()
+
+	defer func() {
+		chk.NoErr(os.RemoveAll(tmpDir))
+	}()
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutTmpSummary(tmpDir),
+	)
+
+	chk.FileExists(tmpDir)
+}
@@ -0,0 +1,284 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const snapshotDirPerm = 0o700
+
+// dirManifest returns the entries below root along with a manifest line for
+// each. Regular files include a fingerprint of their contents so that any
+// modification shows up as a changed line.
+func dirManifest(root string) ([]dirTreeEntry, []string, error) {
+	entries, err := walkDirTree(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines := make([]string, len(entries))
+
+	for i, entry := range entries {
+		lines[i] = entry.String()
+
+		if !entry.mode.IsRegular() {
+			continue
+		}
+
+		data, err := os.ReadFile(
+			filepath.Join(root, filepath.FromSlash(entry.path)),
+		)
+		if err != nil {
+			return nil, nil, err //nolint:wrapcheck // Ok.
+		}
+
+		sum := sha256.Sum256(data)
+		lines[i] += fmt.Sprintf(" sha256:%x", sum[:6])
+	}
+
+	return entries, lines, nil
+}
+
+func copyFile(src, dst string, info fs.FileInfo) error {
+	in, err := os.Open(src) //nolint:gosec // Ok.
+	if err != nil {
+		return err //nolint:wrapcheck // Ok.
+	}
+
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.OpenFile(
+		dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm(),
+	)
+	if err == nil {
+		_, err = io.Copy(out, in)
+
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if err == nil {
+		err = os.Chtimes(dst, info.ModTime(), info.ModTime())
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// copyTree copies the contents of src into dst (created if necessary)
+// preserving file permissions, modification times and symbolic links.
+// Directories are created owner writable; their permissions are applied
+// separately by the caller.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func( //nolint:wrapcheck // Ok.
+		fPath string, d fs.DirEntry, err error,
+	) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, fPath)
+		if err != nil {
+			return err //nolint:wrapcheck // Ok.
+		}
+
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err //nolint:wrapcheck // Ok.
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, snapshotDirPerm) //nolint:wrapcheck // Ok.
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(fPath)
+			if err == nil {
+				err = os.Symlink(link, target)
+			}
+
+			return err //nolint:wrapcheck // Ok.
+		case info.Mode().IsRegular():
+			return copyFile(fPath, target, info)
+		default:
+			return fmt.Errorf("%w: unsupported type: %q", ErrInvalidFile, fPath)
+		}
+	})
+}
+
+// restoreDir replaces the contents of path with those of the snapshot copy
+// and reapplies the recorded directory permissions.
+func restoreDir(
+	path, copyPath string, rootMode fs.FileMode, entries []dirTreeEntry,
+) error {
+	err := os.MkdirAll(path, snapshotDirPerm)
+	if err == nil {
+		err = os.Chmod(path, snapshotDirPerm)
+	}
+
+	var current []os.DirEntry
+
+	if err == nil {
+		current, err = os.ReadDir(path)
+	}
+
+	for i := 0; err == nil && i < len(current); i++ {
+		err = os.RemoveAll(filepath.Join(path, current[i].Name()))
+	}
+
+	if err == nil {
+		err = copyTree(copyPath, path)
+	}
+
+	for i := len(entries) - 1; err == nil && i >= 0; i-- {
+		if entries[i].mode.IsDir() {
+			err = os.Chmod(
+				filepath.Join(path, filepath.FromSlash(entries[i].path)),
+				entries[i].mode.Perm(),
+			)
+		}
+	}
+
+	if err == nil {
+		err = os.Chmod(path, rootMode.Perm())
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+func (chk *Chk) snapshotDir(path string, verify bool) string {
+	chk.t.Helper()
+
+	var rootInfo fs.FileInfo
+
+	// Resolved now in case the test changes the working directory.
+	path, err := filepath.Abs(path)
+	if err == nil {
+		rootInfo, err = os.Stat(path)
+	}
+
+	if err == nil && !rootInfo.IsDir() {
+		err = fmt.Errorf("%w: %q", ErrInvalidDirectory, path)
+	}
+
+	var (
+		entries []dirTreeEntry
+		want    []string
+	)
+
+	if err == nil {
+		entries, want, err = dirManifest(path)
+	}
+
+	if err == nil {
+		rel, relErr := filepath.Rel(path, chk.tmpDirRoot())
+		if relErr == nil && !strings.HasPrefix(rel, "..") {
+			err = fmt.Errorf(
+				"%w: %q contains the temporary directory",
+				ErrInvalidDirectory, path,
+			)
+		}
+	}
+
+	copyPath := ""
+
+	if err == nil {
+		copyPath = filepath.Join(
+			chk.CreateTmpDir(), fmt.Sprint("snapshot", chk.nextTmpID),
+		)
+		chk.nextTmpID++
+
+		err = copyTree(path, copyPath)
+	}
+
+	if err != nil {
+		chk.Error("snapshotDir cause: ", err)
+
+		return ""
+	}
+
+	chk.PushPreReleaseFunc(func() error {
+		chk.t.Helper()
+
+		var (
+			got         []string
+			manifestErr error
+		)
+
+		verify = verify && chk.faultCount == 0
+		if verify {
+			_, got, manifestErr = dirManifest(path)
+		}
+
+		// Restored before reporting any modification as failing the test
+		// may stop it (FailNow) before returning.
+		err := restoreDir(path, copyPath, rootInfo.Mode(), entries)
+		if err != nil {
+			// Keep the snapshot available for manual recovery.
+			chk.keepTmpFiles = true
+
+			return err
+		}
+
+		switch {
+		case !verify:
+		case manifestErr != nil:
+			chk.Error("snapshotDir cause: ", manifestErr)
+		default:
+			chk.compareDirTree(path, got, want, defaultCmpFunc[string])
+		}
+
+		return nil
+	})
+
+	return copyPath
+}
+
+// SnapshotDir copies the existing directory at path into the test’s root
+// temporary directory so that the test may freely modify it. When
+// chk.Release() is called the contents of path are replaced by the
+// snapshot, restoring files, symbolic links, permissions and modification
+// times. The path of the snapshot copy is returned. Should the restore fail
+// the snapshot is kept (as with KeepTmpFiles) for manual recovery.
+func (chk *Chk) SnapshotDir(path string) string {
+	chk.t.Helper()
+
+	return chk.snapshotDir(path, false)
+}
+
+// SnapshotDirUnchanged snapshots the directory at path as with SnapshotDir
+// and, when chk.Release() is called, verifies that it was not modified
+// before restoring it. Any added, removed or changed entry (including a
+// change in permissions or file contents, shown as a content fingerprint)
+// is reported with the same diff as DirTree.
+func (chk *Chk) SnapshotDirUnchanged(path string) string {
+	chk.t.Helper()
+
+	return chk.snapshotDir(path, true)
+}
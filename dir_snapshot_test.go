@@ -0,0 +1,268 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tstChkDirSnapshot(t *testing.T) {
+	t.Run("Restore", chkDirSnapshotTestRestore)
+	t.Run("Unchanged", chkDirSnapshotTestUnchanged)
+	t.Run("Changed", chkDirSnapshotTestChanged)
+	t.Run("Invalid", chkDirSnapshotTestInvalid)
+	t.Run("ChangedFailNow", chkDirSnapshotTestChangedFailNow)
+}
+
+const tstSnapshotFixture = "" +
+	"-- a.txt --\n" +
+	"file a\n" +
+	"-- dir/b.txt --\n" +
+	"file b\n" +
+	"-- dir/run.sh --\n" +
+	"echo run\n"
+
+// tstSnapshotOut lists the trace of snapshotting a directory with an
+// internal chk through to its release.
+func tstSnapshotOut(funcName string, middle ...string) string {
+	out := "" +
+		chkOutCapture("Nothing") +
+		chkOutHelper(funcName) +
+		chkOutHelper("snapshotDir") +
+		chkOutHelper("CreateTmpDir") +
+		chkOutPush("Pre", "") +
+		chkOutPush("Pre", "")
+
+	for _, line := range middle {
+		out += line
+	}
+
+	return out
+}
+
+func chkDirSnapshotTestRestore(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+	defer chk.SetPermExe(chk.SetPermExe(0o700))
+
+	fixture := chk.CreateTmpTreeFromTxtar(tstSnapshotFixture)
+
+	chk.NoErr(os.Symlink("a.txt", filepath.Join(fixture, "link")))
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	snapshot := iChk.SnapshotDir(fixture)
+
+	iChk.DirTreeTxtar(snapshot, tstSnapshotFixture+"-- link --\n")
+
+	chk.NoErr(os.WriteFile(filepath.Join(fixture, "a.txt"), nil, 0o600))
+	chk.NoErr(os.RemoveAll(filepath.Join(fixture, "dir")))
+	chk.NoErr(os.Remove(filepath.Join(fixture, "link")))
+	chk.NoErr(os.WriteFile(filepath.Join(fixture, "new.txt"), nil, 0o600))
+	chk.NoErr(os.Chmod(fixture, 0o755))
+
+	iChk.Release()
+
+	chk.DirTree(fixture,
+		"-rw------- a.txt",
+		"drwx------ dir/",
+		"-rw------- dir/b.txt",
+		"-rwx------ dir/run.sh",
+		"Lrwxrwxrwx link",
+	)
+	chk.FileContents(filepath.Join(fixture, "a.txt"), "file a")
+	chk.FileMode(fixture, os.ModeDir|0o700)
+	chk.Symlink(filepath.Join(fixture, "link"), "a.txt")
+	chk.FileNotExists(snapshot)
+
+	iT.check(t,
+		tstSnapshotOut("SnapshotDir",
+			chkOutHelper("DirTreeTxtar"),
+			chkOutHelper("dirTreeContents"),
+			chkOutHelper("compareDirTree"),
+			chkOutRelease(),
+			chkOutPush("Pre", "func2"),
+			chkOutHelper("snapshotDir.func1"),
+			chkOutPush("Pre", "func1"),
+		),
+	)
+}
+
+func chkDirSnapshotTestUnchanged(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fixture := chk.CreateTmpTreeFromTxtar(tstSnapshotFixture)
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.SnapshotDirUnchanged(fixture)
+
+	// Rewriting identical contents is not a modification.
+	chk.NoErr(os.WriteFile(
+		filepath.Join(fixture, "a.txt"), []byte("file a\n"), 0o600,
+	))
+
+	iChk.Release()
+	iT.check(t,
+		tstSnapshotOut("SnapshotDirUnchanged",
+			chkOutRelease(),
+			chkOutPush("Pre", "func2"),
+			chkOutHelper("snapshotDir.func1"),
+			chkOutHelper("compareDirTree"),
+			chkOutPush("Pre", "func1"),
+		),
+	)
+}
+
+func chkDirSnapshotTestChanged(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+	defer chk.SetPermExe(chk.SetPermExe(0o700))
+
+	fixture := chk.CreateTmpTreeFromTxtar(tstSnapshotFixture)
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	snapshot := iChk.SnapshotDirUnchanged(fixture)
+
+	chk.NoErr(os.WriteFile(
+		filepath.Join(fixture, "a.txt"), []byte("changed\n"), 0o600,
+	))
+	chk.NoErr(os.Chmod(filepath.Join(fixture, "dir", "run.sh"), 0o600))
+
+	iChk.Release()
+
+	chk.DirTreeTxtar(fixture, tstSnapshotFixture)
+	chk.FileMode(filepath.Join(fixture, "dir", "run.sh"), 0o700)
+
+	// A failing test keeps its temporary files.
+	chk.FileExists(snapshot)
+	chk.NoErr(removeTestDir(filepath.Dir(snapshot)))
+
+	iT.check(t,
+		tstSnapshotOut("SnapshotDirUnchanged",
+			chkOutRelease(),
+			chkOutPush("Pre", "func2"),
+			chkOutHelper("snapshotDir.func1"),
+			chkOutHelper("compareDirTree"),
+			chkOutError(
+				"Unexpected Directory Tree "+fixture+
+					": got (4 lines) - want (4 lines)",
+				chkOutLnChanged(
+					"0", "0",
+					"-rw------- a.txt sha256:"+
+						markAsChg("7f8b1dfc466b", "d048a00658e4", diffMerge),
+				),
+				chkOutLnSame("1", "1", "drwx------ dir/"),
+				chkOutLnSame(
+					"2", "2", "-rw------- dir/b.txt sha256:a633061912d3",
+				),
+				chkOutLnChanged(
+					"3", "3",
+					"-rw"+markAsChg("-", "x", diffMerge)+
+						"------ dir/run.sh sha256:b77d933fde44",
+				),
+			),
+			chkOutPush("Pre", "func1"),
+			chkOutTmpSummary(filepath.Dir(snapshot),
+				"drwx------ snapshot0/",
+				"-rw------- snapshot0/a.txt",
+				"drwx------ snapshot0/dir/",
+				"-rw------- snapshot0/dir/b.txt",
+				"-rwx------ snapshot0/dir/run.sh",
+			),
+		),
+	)
+}
+
+func chkDirSnapshotTestInvalid(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fPath := chk.CreateTmpFileAs("", "notDir", nil)
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.Str(iChk.SnapshotDir(fPath), "")
+	iChk.SnapshotDir(settingTmpDir)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("SnapshotDir"),
+		chkOutHelper("snapshotDir"),
+		chkOutError(
+			"snapshotDir cause: "+ErrInvalidDirectory.Error()+
+				": \""+fPath+"\"",
+		),
+		chkOutHelper("SnapshotDir"),
+		chkOutHelper("snapshotDir"),
+		chkOutError(
+			"snapshotDir cause: "+ErrInvalidDirectory.Error()+
+				": \""+settingTmpDir+"\" contains the temporary directory",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkDirSnapshotTestChangedFailNow(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fixture := chk.CreateTmpTreeFromTxtar(tstSnapshotFixture)
+	tmpDir := ""
+
+	gT := runGoexitTest(func(gT *gTst) {
+		gChk := CaptureNothing(gT)
+		defer gChk.Release()
+
+		tmpDir = gChk.CreateTmpDir()
+
+		gChk.SnapshotDirUnchanged(fixture)
+
+		chk.NoErr(os.WriteFile(
+			filepath.Join(fixture, "a.txt"), []byte("MUTATED\n"), 0o600,
+		))
+	})
+
+	chk.NoErr(removeTestDir(tmpDir))
+
+	// Restored even though reporting the change stopped the test.
+	chk.FileContents(filepath.Join(fixture, "a.txt"), "file a")
+	chk.True(strings.Contains(gT.output, "Unexpected Directory Tree"))
+	chk.True(strings.Contains(gT.output, "Fail Now\n"))
+}
@@ -0,0 +1,162 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// removeTestLink removes a symbolic link or other non directory entry
+// without following it.
+func removeTestLink(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err == nil && fi.IsDir() {
+		err = fmt.Errorf("%w: %q", ErrInvalidFile, path)
+	}
+
+	if err == nil {
+		err = os.Remove(path)
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// createSpecial resolves path under the test’s root temporary directory,
+// removes anything already there and calls create to make the new entry,
+// registering its removal when the test completes successfully.
+func (chk *Chk) createSpecial(
+	path string, create func(string) error,
+) string {
+	chk.t.Helper()
+
+	tmpDir := chk.CreateTmpDir()
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(tmpDir, path)
+	}
+
+	err := removeTestLink(path)
+	if err == nil {
+		err = create(path)
+	}
+
+	if err == nil {
+		chk.PushPreReleaseFunc(func() error {
+			if chk.removeTmpFiles() {
+				return removeTestLink(path)
+			}
+
+			return nil
+		})
+	}
+
+	if err != nil {
+		chk.Error("createSpecial cause: ", err)
+	}
+
+	return path
+}
+
+// CreateTmpSymlink removes any existing entry and creates a symbolic link
+// at path pointing to target, returning the absolute path of the link. A
+// relative path is placed under the test’s root temporary directory while
+// target is stored verbatim and need not exist, allowing broken links to be
+// tested. Unless KeepTmpFiles is called, the link is removed automatically
+// when the test completes successfully.
+func (chk *Chk) CreateTmpSymlink(path, target string) string {
+	chk.t.Helper()
+
+	return chk.createSpecial(path, func(link string) error {
+		return os.Symlink(target, link)
+	})
+}
+
+// CreateTmpFIFO removes any existing entry and creates a named pipe at
+// path, returning its absolute path. A relative path is placed under the
+// test’s root temporary directory and permissions follow the current file
+// mode setting. Named pipes are only supported on Unix systems; elsewhere
+// the test fails. Unless KeepTmpFiles is called, the pipe is removed
+// automatically when the test completes successfully.
+func (chk *Chk) CreateTmpFIFO(path string) string {
+	chk.t.Helper()
+
+	return chk.createSpecial(path, func(fifo string) error {
+		return mkfifo(fifo, settingPermFile)
+	})
+}
+
+// CreateTmpFileWithPerm removes any existing file and creates a new file
+// as with CreateTmpFileAs but with exactly the permissions given (unaffected
+// by the umask) so that, for example, an unreadable (0o200) or unwritable
+// (0o400) file can be used to test permission errors. Unless KeepTmpFiles
+// is called, the file is removed automatically when the test completes
+// successfully.
+func (chk *Chk) CreateTmpFileWithPerm(
+	path, fName string, data []byte, perm os.FileMode,
+) string {
+	chk.t.Helper()
+
+	fPath := chk.createFile(path, fName, data, perm)
+
+	err := os.Chmod(fPath, perm)
+	if err != nil {
+		chk.Error("createFile cause: ", err)
+	}
+
+	return fPath
+}
+
+// CreateTmpSubDirWithPerm creates subdirectories as with CreateTmpSubDir
+// and then sets the permissions of the final one exactly to perm so that,
+// for example, an unreadable (0o300) or unwritable (0o500) directory can
+// be used to test permission errors. Before the temporary directory is
+// removed its permissions are restored to the current directory mode
+// setting (as removeTestDir does for the root) so cleanup succeeds.
+func (chk *Chk) CreateTmpSubDirWithPerm(
+	perm os.FileMode, subDirs ...string,
+) string {
+	chk.t.Helper()
+
+	fullPath := chk.CreateTmpSubDir(subDirs...)
+
+	err := os.Chmod(fullPath, perm)
+	if err == nil {
+		chk.PushPreReleaseFunc(func() error {
+			err := os.Chmod(fullPath, settingPermDir)
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err //nolint:wrapcheck // Ok.
+		})
+	}
+
+	if err != nil {
+		chk.Error("createTmpSubDir caused: ", err)
+	}
+
+	return fullPath
+}
@@ -0,0 +1,165 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func tstChkDirSpecial(t *testing.T) {
+	t.Run("Symlink", chkDirSpecialTestSymlink)
+	t.Run("FIFO", chkDirSpecialTestFIFO)
+	t.Run("FileWithPerm", chkDirSpecialTestFileWithPerm)
+	t.Run("SubDirWithPerm", chkDirSpecialTestSubDirWithPerm)
+	t.Run("Invalid", chkDirSpecialTestInvalid)
+}
+
+func chkDirSpecialTestSymlink(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	link := iChk.CreateTmpSymlink("broken", "doesNotExist")
+	chk.Str(link, filepath.Join(iChk.CreateTmpDir(), "broken"))
+
+	chk.Symlink(link, "doesNotExist")
+
+	_, err := os.Stat(link)
+	chk.True(errors.Is(err, fs.ErrNotExist))
+
+	// Replacing an existing link.
+	iChk.CreateTmpSymlink(link, "other")
+	chk.Symlink(link, "other")
+
+	iChk.Release()
+
+	chk.FileNotExists(link)
+	chk.FileNotExists(filepath.Dir(link))
+
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpSymlink"),
+		chkOutHelper("createSpecial"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutPush("Pre", ""),
+		chkOutHelper("CreateTmpSymlink"),
+		chkOutHelper("createSpecial"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutPush("Pre", "func2"),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+func chkDirSpecialTestFIFO(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fifo := chk.CreateTmpFIFO("pipe")
+
+	info, err := os.Lstat(fifo)
+	chk.NoErr(err)
+	chk.True(info.Mode()&fs.ModeNamedPipe != 0)
+}
+
+func chkDirSpecialTestFileWithPerm(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	writeOnly := chk.CreateTmpFileWithPerm("", "writeOnly", []byte("x"), 0o222)
+	readOnly := chk.CreateTmpFileWithPerm("", "readOnly", []byte("y"), 0o400)
+
+	chk.FileMode(writeOnly, 0o222)
+	chk.FileMode(readOnly, 0o400)
+}
+
+func chkDirSpecialTestSubDirWithPerm(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	parent := iChk.CreateTmpSubDir("locked")
+	iChk.CreateTmpFileAs("locked", "inside.txt", nil)
+
+	locked := iChk.CreateTmpSubDirWithPerm(0o000, "locked")
+	chk.Str(locked, parent)
+	chk.FileMode(locked, fs.ModeDir)
+
+	iChk.Release()
+
+	chk.FileNotExists(parent)
+
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("CreateTmpFileAs"),
+		chkOutHelper("createFile"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("CreateTmpSubDirWithPerm"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutPush("Pre", "func2"),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+func chkDirSpecialTestInvalid(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpSubDir("aDir")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.CreateTmpSymlink(dir, "target")
+
+	iChk.Release()
+
+	chk.NoErr(removeTestDir(iChk.CreateTmpDir()))
+
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpSymlink"),
+		chkOutHelper("createSpecial"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutError(
+			"createSpecial cause: "+ErrInvalidFile.Error()+": \""+dir+"\"",
+		),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutTmpSummary(iChk.CreateTmpDir()),
+	)
+}
@@ -372,6 +372,8 @@ func chkDirTestCreateTmpFileEmptyKeepTmp(t *testing.T) {
 	chk := CaptureNothing(iT)
 	iT.chk = chk
 
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
 	chk.KeepTmpFiles()
 
 	tmpDir := chk.CreateTmpDir()
@@ -401,6 +403,7 @@ func chkDirTestCreateTmpFileEmptyKeepTmp(t *testing.T) {
 		chkOutRelease(),
 		chkOutPush("Pre", "func2"),
 		chkOutPush("Pre", "func1"),
+		chkOutTmpSummary(tmpDir, "-rw------- tmpFile0.tmp"),
 	)
 }
 
@@ -0,0 +1,316 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// dirTreeModeRE matches the fs.FileMode.String() form of a mode.
+var dirTreeModeRE = regexp.MustCompile(`^[-dalTLDpSucC?]+[-rwxsStT]{9}$`)
+
+// dirTreeEntry is a single path found under a directory tree. Directory
+// paths end in "/".
+type dirTreeEntry struct {
+	path string
+	mode fs.FileMode
+}
+
+func (e dirTreeEntry) String() string {
+	return e.mode.String() + " " + e.path
+}
+
+// walkDirTree returns every entry below root (excluding root itself) in
+// lexical order with slash separated relative paths.
+func walkDirTree(root string) ([]dirTreeEntry, error) {
+	var entries []dirTreeEntry
+
+	err := filepath.WalkDir(root, func(
+		fPath string, d fs.DirEntry, err error,
+	) error {
+		if err != nil {
+			return err
+		}
+
+		if fPath == root {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err //nolint:wrapcheck // Ok.
+		}
+
+		rel, err := filepath.Rel(root, fPath)
+		if err != nil {
+			return err //nolint:wrapcheck // Ok.
+		}
+
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			rel += "/"
+		}
+
+		entries = append(entries, dirTreeEntry{path: rel, mode: info.Mode()})
+
+		return nil
+	})
+
+	return entries, err //nolint:wrapcheck // Ok.
+}
+
+// dirTreeCmp matches a got manifest line against a want line which may omit
+// the mode.
+func dirTreeCmp(got, want string) bool {
+	if got == want {
+		return true
+	}
+
+	mode, _, found := strings.Cut(want, " ")
+	if found && dirTreeModeRE.MatchString(mode) {
+		return false
+	}
+
+	_, gotPath, _ := strings.Cut(got, " ")
+
+	return gotPath == want
+}
+
+// dirTreeLinePath returns the path of a manifest line dropping any leading
+// mode.
+func dirTreeLinePath(line string) string {
+	mode, linePath, found := strings.Cut(line, " ")
+	if found && dirTreeModeRE.MatchString(mode) {
+		return linePath
+	}
+
+	return line
+}
+
+// dirTreeLess orders slash separated paths as filepath.WalkDir visits
+// them: parents before their children and siblings by name.
+func dirTreeLess(a, b string) bool {
+	aParts := strings.Split(strings.TrimSuffix(a, "/"), "/")
+	bParts := strings.Split(strings.TrimSuffix(b, "/"), "/")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if aParts[i] != bParts[i] {
+			return aParts[i] < bParts[i]
+		}
+	}
+
+	return len(aParts) < len(bParts)
+}
+
+// dirTreeWant expands manifest lines, each a slash separated path
+// optionally prefixed by a mode, into a list in walk order including every
+// implied parent directory. Paths ending in "/" are directories. When a
+// path is given more than once the line carrying a mode is kept.
+func dirTreeWant(lines []string) []string {
+	byPath := make(map[string]string)
+
+	for _, line := range lines {
+		linePath := dirTreeLinePath(line)
+		if prev, ok := byPath[linePath]; !ok || prev == linePath {
+			byPath[linePath] = line
+		}
+
+		for dir := path.Dir(strings.TrimSuffix(linePath, "/")); dir != "."; {
+			if _, ok := byPath[dir+"/"]; !ok {
+				byPath[dir+"/"] = dir + "/"
+			}
+
+			dir = path.Dir(dir)
+		}
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for linePath := range byPath {
+		paths = append(paths, linePath)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return dirTreeLess(paths[i], paths[j])
+	})
+
+	want := make([]string, len(paths))
+	for i, linePath := range paths {
+		want[i] = byPath[linePath]
+	}
+
+	return want
+}
+
+// compareDirTree reports differences between got and want manifests.
+func (chk *Chk) compareDirTree(
+	root string, got, want []string, cmp func(a, b string) bool,
+) bool {
+	chk.t.Helper()
+
+	ret := compareSlices(
+		"Unexpected Directory Tree "+root,
+		chk.prepareSlice(keepLine, got...),
+		chk.prepareSlice(keepLine, want...),
+		settingDiffSlice,
+		settingDiffChars,
+		cmp,
+		chk.isStringify,
+	)
+
+	if ret != "" {
+		chk.Error(ret)
+
+		return false
+	}
+
+	return true
+}
+
+// dirTreeContents compares the entries found under root against the want
+// map of manifest lines (paths optionally prefixed by a mode) to contents.
+// Directory paths end in "/" and any contents given for them are ignored.
+func (chk *Chk) dirTreeContents(root string, want map[string]string) bool {
+	chk.t.Helper()
+
+	entries, err := walkDirTree(root)
+	if err != nil {
+		chk.Error("dirTree cause: ", err)
+
+		return false
+	}
+
+	lines := make([]string, 0, len(want))
+	contents := make(map[string]string, len(want))
+	withMode := make(map[string]bool)
+
+	for line, data := range want {
+		linePath := dirTreeLinePath(line)
+		lines = append(lines, line)
+		contents[linePath] = data
+		withMode[linePath] = withMode[linePath] || line != linePath
+	}
+
+	// Modes are only shown for the entries expected to have one.
+	got := make([]string, len(entries))
+	for i, entry := range entries {
+		got[i] = entry.path
+		if withMode[entry.path] {
+			got[i] = entry.String()
+		}
+	}
+
+	result := chk.compareDirTree(
+		root, got, dirTreeWant(lines), defaultCmpFunc[string],
+	)
+
+	for _, entry := range entries {
+		wantData, ok := contents[entry.path]
+		if !ok || !entry.mode.IsRegular() {
+			continue
+		}
+
+		gotData, err := os.ReadFile(
+			filepath.Join(root, filepath.FromSlash(entry.path)),
+		)
+		if err != nil {
+			chk.Error("dirTree cause: ", err)
+
+			result = false
+
+			continue
+		}
+
+		if !chk.Strf(string(gotData), wantData, "file %s", entry.path) {
+			result = false
+		}
+	}
+
+	return result
+}
+
+// DirTree walks the directory root and compares every entry below it
+// against the want manifest, reporting missing and extra entries with the
+// standard slice diff. Each want line is a slash separated relative path,
+// with directories ending in "/", optionally prefixed by the expected mode
+// as formatted by fs.FileMode.String():
+//
+//	chk.DirTree(root,
+//	    "drwx------ bin/",
+//	    "-rwx------ bin/run",
+//	    "README.md",
+//	)
+//
+// Lines without a mode match any mode. Entries may be listed in any order
+// and parent directories are implied. Returns true if the tree matches.
+func (chk *Chk) DirTree(root string, want ...string) bool {
+	chk.t.Helper()
+
+	entries, err := walkDirTree(root)
+	if err != nil {
+		chk.Error("dirTree cause: ", err)
+
+		return false
+	}
+
+	got := make([]string, len(entries))
+	for i, entry := range entries {
+		got[i] = entry.String()
+	}
+
+	return chk.compareDirTree(root, got, dirTreeWant(want), dirTreeCmp)
+}
+
+// DirTreeMap walks the directory root comparing the set of entries below it
+// against the keys of want (slash separated relative paths) and the
+// contents of each regular file against the corresponding value. A key
+// ending in "/" denotes a directory and parent directories are implied. As
+// with DirTree a key may be prefixed by the expected mode, for example
+// "-rwx------ bin/run", which is then compared too. A mismatch in the set
+// of entries is reported with the standard slice diff while differing
+// contents are reported per file as with Str. Returns true if the tree
+// matches.
+func (chk *Chk) DirTreeMap(root string, want map[string]string) bool {
+	chk.t.Helper()
+
+	return chk.dirTreeContents(root, want)
+}
+
+// DirTreeTxtar walks the directory root comparing it against the files of
+// a txtar archive (see golang.org/x/tools/txtar) as with DirTreeMap. An
+// entry whose name ends in "/" denotes a directory, a name may be prefixed
+// by the expected mode ("-- -rwx------ bin/run --") and the archive
+// comment is ignored. Returns true if the tree matches.
+func (chk *Chk) DirTreeTxtar(root, archive string) bool {
+	chk.t.Helper()
+
+	files := parseTxtar([]byte(archive)).files
+	want := make(map[string]string, len(files))
+
+	for _, file := range files {
+		want[file.name] = string(file.data)
+	}
+
+	return chk.dirTreeContents(root, want)
+}
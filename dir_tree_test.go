@@ -0,0 +1,303 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func tstChkDirTree(t *testing.T) {
+	t.Run("Less", chkDirTreeTestLess)
+	t.Run("Good", chkDirTreeTestGood)
+	t.Run("Unordered", chkDirTreeTestUnordered)
+	t.Run("BadModes", chkDirTreeTestBadModes)
+	t.Run("ImpliedParents", chkDirTreeTestImpliedParents)
+	t.Run("MapGood", chkDirTreeTestMapGood)
+	t.Run("MapModes", chkDirTreeTestMapModes)
+	t.Run("MapBadMode", chkDirTreeTestMapBadMode)
+	t.Run("TxtarBad", chkDirTreeTestTxtarBad)
+	t.Run("Missing", chkDirTreeTestMissing)
+}
+
+func chkDirTreeTestLess(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.StrSlice(
+		dirTreeWant([]string{"a-b", "a/x", "a/", "c/d/", "b"}),
+		[]string{"a/", "a/x", "a-b", "b", "c/", "c/d/"},
+	)
+}
+
+func chkDirTreeTestGood(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+	defer chk.SetPermExe(chk.SetPermExe(0o700))
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- bin/run.sh --\n" +
+		"echo run\n" +
+		"-- bin-old/ --\n" +
+		"-- README.md --\n" +
+		"# Readme\n",
+	)
+
+	chk.DirTree(root,
+		"README.md",
+		"drwx------ bin/",
+		"-rwx------ bin/run.sh",
+		"bin-old/",
+	)
+
+	chk.DirTreeTxtar(root, ""+
+		"-- README.md --\n"+
+		"# Readme\n"+
+		"-- bin/run.sh --\n"+
+		"echo run\n"+
+		"-- bin-old/ --\n",
+	)
+}
+
+func chkDirTreeTestUnordered(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- b/c.txt --\n" +
+		"-- a b.txt --\n" +
+		"-- a/z.txt --\n",
+	)
+
+	chk.DirTree(root,
+		"-rw------- b/c.txt",
+		"a/z.txt",
+		"drwx------ b/",
+		"a b.txt",
+		"a/",
+	)
+}
+
+func chkDirTreeTestBadModes(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	root := chk.CreateTmpTreeFromTxtar("-- a.txt --\n")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.DirTree(root,
+		"-rw-r--r-- a.txt",
+		"b.txt",
+	)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("DirTree"),
+		chkOutHelper("compareDirTree"),
+		chkOutError(
+			"Unexpected Directory Tree "+root+
+				": got (1 lines) - want (2 lines)",
+			chkOutLnChanged(
+				"0", "0",
+				"-rw-"+markAsChg("----", "r--r", diffMerge)+"-- a.txt",
+			),
+			chkOutLnWnt("1", "b.txt"),
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkDirTreeTestMapGood(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- a/b/c.txt --\n" +
+		"deep\n" +
+		"-- a/empty/ --\n",
+	)
+
+	chk.DirTreeMap(root, map[string]string{
+		"a/b/c.txt": "deep\n",
+		"a/empty/":  "",
+	})
+}
+
+func chkDirTreeTestImpliedParents(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- bin/run --\n" +
+		"-- a/b/c/ --\n",
+	)
+
+	chk.DirTree(root, "bin/run", "a/b/c/")
+	chk.DirTree(root, "bin/run", "drwx------ a/", "a/b/c/")
+}
+
+func chkDirTreeTestMapModes(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+	defer chk.SetPermExe(chk.SetPermExe(0o700))
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- bin/run.sh --\n" +
+		"echo run\n" +
+		"-- a.txt --\n" +
+		"a\n",
+	)
+
+	chk.DirTreeMap(root, map[string]string{
+		"-rwx------ bin/run.sh": "echo run\n",
+		"drwx------ bin/":       "",
+		"a.txt":                 "a\n",
+	})
+
+	chk.DirTreeTxtar(root, ""+
+		"-- -rw------- a.txt --\n"+
+		"a\n"+
+		"-- -rwx------ bin/run.sh --\n"+
+		"echo run\n",
+	)
+}
+
+func chkDirTreeTestMapBadMode(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- a.txt --\n" +
+		"a\n" +
+		"-- b.txt --\n" +
+		"b\n",
+	)
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.DirTreeMap(root, map[string]string{
+		"-rw-r--r-- a.txt": "a\n",
+		"b.txt":            "b\n",
+	})
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("DirTreeMap"),
+		chkOutHelper("dirTreeContents"),
+		chkOutHelper("compareDirTree"),
+		chkOutError(
+			"Unexpected Directory Tree "+root+
+				": got (2 lines) - want (2 lines)",
+			chkOutLnChanged(
+				"0", "0",
+				"-rw-"+markAsChg("----", "r--r", diffMerge)+"-- a.txt",
+			),
+			chkOutLnSame("1", "1", "b.txt"),
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkDirTreeTestTxtarBad(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"-- a.txt --\n" +
+		"got a\n" +
+		"-- extra.txt --\n" +
+		"extra\n",
+	)
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.DirTreeTxtar(root, ""+
+		"-- a.txt --\n"+
+		"want a\n"+
+		"-- missing/b.txt --\n"+
+		"b\n",
+	)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("DirTreeTxtar"),
+		chkOutHelper("dirTreeContents"),
+		chkOutHelper("compareDirTree"),
+		chkOutError(
+			"Unexpected Directory Tree "+root+
+				": got (2 lines) - want (3 lines)",
+			chkOutLnSame("0", "0", "a.txt"),
+			chkOutLnChanged("1", "1", "extra.txt", "missing/"),
+			chkOutLnWnt("2", "missing/b.txt"),
+		),
+		chkOutHelper("Strf"),
+		chkOutHelper("errChkf"),
+		chkOutError(
+			chkOutCommonMsg("file a.txt", "string"),
+			g(markAsChg("go", "", diffGot)+`t a\n`),
+			w(markAsChg("", "wan", diffWant)+`t a\n`),
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkDirTreeTestMissing(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	root := filepath.Join(settingTmpDir, "doesNotExist")
+
+	chk.DirTree(root)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("DirTree"),
+		chkOutError(
+			"dirTree cause: lstat "+root+": no such file or directory",
+		),
+		chkOutRelease(),
+	)
+}
@@ -0,0 +1,114 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// txtarIsExecutable reports if a txtar entry should be created with the
+// executable permission: scripts named *.sh or starting with a shebang.
+func txtarIsExecutable(name string, data []byte) bool {
+	return strings.HasSuffix(name, ".sh") || bytes.HasPrefix(data, []byte("#!"))
+}
+
+// createTmpTree materializes every entry of the archive under root.
+func createTmpTree(root string, archive *txtarArchive) error {
+	for _, file := range archive.files {
+		name := strings.TrimSuffix(file.name, "/")
+		isDir := name != file.name
+
+		if !fs.ValidPath(name) || name == "." {
+			return fmt.Errorf("%w: %q", ErrInvalidFile, file.name)
+		}
+
+		fPath := filepath.Join(root, filepath.FromSlash(name))
+
+		if isDir {
+			err := os.MkdirAll(fPath, settingPermDir)
+			if err != nil {
+				return err //nolint:wrapcheck // Ok.
+			}
+
+			continue
+		}
+
+		err := os.MkdirAll(filepath.Dir(fPath), settingPermDir)
+		if err != nil {
+			return err //nolint:wrapcheck // Ok.
+		}
+
+		perm := settingPermFile
+		if txtarIsExecutable(name, file.data) {
+			perm = settingPermExe
+		}
+
+		err = os.WriteFile(fPath, file.data, perm)
+		if err != nil {
+			return err //nolint:wrapcheck // Ok.
+		}
+	}
+
+	return nil
+}
+
+// CreateTmpTreeFromTxtar creates every file of the txtar archive (see
+// golang.org/x/tools/txtar) under the test’s root temporary directory,
+// returning the root path. Parent directories are created as needed using
+// the current directory mode setting and an entry whose name ends in "/"
+// creates an empty directory. Files use the current file mode setting
+// except those named *.sh or starting with "#!" which use the current
+// executable mode setting. The archive comment is ignored. Unless
+// KeepTmpFiles is called, the tree is removed automatically when the test
+// completes successfully.
+func (chk *Chk) CreateTmpTreeFromTxtar(archive string) string {
+	chk.t.Helper()
+
+	root := chk.CreateTmpDir()
+
+	err := createTmpTree(root, parseTxtar([]byte(archive)))
+	if err != nil {
+		chk.Error("createTmpTree cause: ", err)
+	}
+
+	return root
+}
+
+// CreateTmpTreeFromTxtarFile reads a txtar archive from the named file
+// (typically kept under the package's testdata directory) and creates its
+// contents as with CreateTmpTreeFromTxtar, returning the root path. If the
+// archive cannot be read the failure is reported, nothing is created and ""
+// is returned.
+func (chk *Chk) CreateTmpTreeFromTxtarFile(fPath string) string {
+	chk.t.Helper()
+
+	data, err := os.ReadFile(fPath) //nolint:gosec // Test fixture.
+	if err != nil {
+		chk.Error("createTmpTree cause: ", err)
+
+		return ""
+	}
+
+	return chk.CreateTmpTreeFromTxtar(string(data))
+}
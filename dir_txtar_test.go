@@ -0,0 +1,148 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func tstChkDirTxtar(t *testing.T) {
+	t.Run("Tree", chkDirTxtarTestTree)
+	t.Run("File", chkDirTxtarTestFile)
+	t.Run("MissingFile", chkDirTxtarTestMissingFile)
+	t.Run("InvalidName", chkDirTxtarTestInvalidName)
+	t.Run("Removed", chkDirTxtarTestRemoved)
+}
+
+func chkDirTxtarTestTree(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+	defer chk.SetPermExe(chk.SetPermExe(0o700))
+
+	root := chk.CreateTmpTreeFromTxtar("" +
+		"Comment is not created.\n" +
+		"-- a.txt --\n" +
+		"file a\n" +
+		"-- dir/sub/b.txt --\n" +
+		"file b\n" +
+		"-- dir/build.sh --\n" +
+		"echo build\n" +
+		"-- dir/tool --\n" +
+		"#!/bin/sh\n" +
+		"-- empty/ --\n",
+	)
+
+	chk.Str(root, chk.CreateTmpDir())
+
+	data, err := os.ReadFile(filepath.Join(root, "dir", "sub", "b.txt"))
+	chk.NoErr(err)
+	chk.Str(string(data), "file b\n")
+
+	perm := func(name string) string {
+		info, err := os.Stat(filepath.Join(root, filepath.FromSlash(name)))
+		chk.NoErr(err)
+
+		return info.Mode().String()
+	}
+
+	chk.Str(perm("a.txt"), "-rw-------")
+	chk.Str(perm("dir/build.sh"), "-rwx------")
+	chk.Str(perm("dir/tool"), "-rwx------")
+	chk.Str(perm("dir/sub"), "drwx------")
+	chk.Str(perm("empty"), "drwx------")
+
+	entries, err := os.ReadDir(root)
+	chk.NoErr(err)
+	chk.Int(len(entries), 3)
+}
+
+func chkDirTxtarTestFile(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	root := chk.CreateTmpTreeFromTxtarFile(
+		filepath.Join("testdata", "tree.txtar"),
+	)
+
+	data, err := os.ReadFile(filepath.Join(root, "cmd", "run"))
+	chk.NoErr(err)
+	chk.Str(string(data), "#!/bin/sh\necho run\n")
+
+	data, err = os.ReadFile(filepath.Join(root, "README.md"))
+	chk.NoErr(err)
+	chk.Str(string(data), "# Fixture\n")
+}
+
+func chkDirTxtarTestMissingFile(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	fPath := filepath.Join("testdata", "doesNotExist.txtar")
+
+	chk.Str(chk.CreateTmpTreeFromTxtarFile(fPath), "")
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpTreeFromTxtarFile"),
+		chkOutError(
+			"createTmpTree cause: open "+fPath+": no such file or directory",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkDirTxtarTestInvalidName(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	root := chk.CreateTmpDir()
+
+	chk.Err(
+		createTmpTree(root, parseTxtar([]byte("-- ../escape --\n"))),
+		ErrInvalidFile.Error()+`: "../escape"`,
+	)
+
+	_, err := os.Stat(filepath.Join(root, "..", "escape"))
+	chk.True(errors.Is(err, fs.ErrNotExist))
+}
+
+func chkDirTxtarTestRemoved(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	inner := CaptureNothing(t)
+	root := inner.CreateTmpTreeFromTxtar("-- a/b/c.txt --\ndata\n")
+
+	_, err := os.Stat(filepath.Join(root, "a", "b", "c.txt"))
+	chk.NoErr(err)
+
+	inner.Release()
+
+	_, err = os.Stat(root)
+	chk.True(errors.Is(err, fs.ErrNotExist))
+}
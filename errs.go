@@ -9,4 +9,8 @@ var (
 	ErrInvalidFile       = errors.New("invalid file")
 	ErrReadPastEndOfData = errors.New("read past end of data")
 	ErrForcedOutOfSpace  = errors.New("forced out of space")
+	ErrInvalidLineMatch  = errors.New("invalid line matcher")
+	ErrInvalidSeek       = errors.New("invalid seek")
+	ErrListenerBacklog   = errors.New("fake listener backlog full")
+	ErrDirNotEmpty       = errors.New("directory not empty")
 )
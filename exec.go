@@ -0,0 +1,139 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	execExitCodeTypeName = "exit code"
+	execWaitDelay        = 100 * time.Millisecond
+)
+
+// ExecResult holds the outcome of a command run by Chk.Exec or Chk.ExecIn.
+// Its assertion methods report failures through the Chk that ran the
+// command.
+type ExecResult struct {
+	chk      *Chk
+	cmdLine  string
+	stdout   string
+	stderr   string
+	exitCode int
+}
+
+// ExitCode compares the exit code of the command against want. A command
+// that could not be started or was killed reports an exit code of -1.
+func (r *ExecResult) ExitCode(want int) bool {
+	r.chk.t.Helper()
+
+	if r.exitCode == want {
+		return true
+	}
+
+	return r.chk.errGotWnt(execExitCodeTypeName, r.exitCode, want, r.cmdLine)
+}
+
+// Stdout compares the standard output of the command against wantLines
+// with the same substitutions and line differences as chk.Stdout.
+func (r *ExecResult) Stdout(wantLines ...string) bool {
+	r.chk.t.Helper()
+
+	return r.chk.compareLog(
+		"", "exec stdout", r.stdout, 0, keepLine, keepLine, wantLines...,
+	)
+}
+
+// Stderr compares the standard error of the command against wantLines
+// with the same substitutions and line differences as chk.Stderr.
+func (r *ExecResult) Stderr(wantLines ...string) bool {
+	r.chk.t.Helper()
+
+	return r.chk.compareLog(
+		"", "exec stderr", r.stderr, 0, keepLine, keepLine, wantLines...,
+	)
+}
+
+// Exec runs cmd with args in the test's root temporary directory (see
+// CreateTmpDir) and returns the result for checking. See ExecIn.
+func (chk *Chk) Exec(cmd string, args ...string) *ExecResult {
+	chk.t.Helper()
+
+	return chk.ExecIn(chk.CreateTmpDir(), cmd, args...)
+}
+
+// ExecIn runs cmd with args in the directory dir, capturing its standard
+// output and error, and returns the result for checking. The command
+// inherits the environment of the test (including any fake commands
+// placed on the PATH by FakeCommand) and reads from an empty standard
+// input. A command that cannot be started, or that is still running after
+// SettingExecTimeout, fails the test and is killed. A non-zero exit code
+// alone is not a failure: check it with ExitCode.
+func (chk *Chk) ExecIn(dir, cmd string, args ...string) *ExecResult {
+	chk.t.Helper()
+
+	timeout := settingExecTimeout
+	result := &ExecResult{
+		chk:      chk,
+		cmdLine:  CommandCall{Args: append([]string{cmd}, args...)}.String(),
+		exitCode: -1,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, cmd, args...)
+	command.Dir = dir
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	command.WaitDelay = execWaitDelay
+
+	err := command.Run()
+
+	result.stdout = stdout.String()
+	result.stderr = stderr.String()
+
+	exitErr := new(exec.ExitError)
+
+	switch {
+	case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		chk.Error(
+			errMsgHeader("exec", result.cmdLine),
+			"timeout after ", timeout,
+		)
+	case errors.As(err, &exitErr):
+		result.exitCode = exitErr.ExitCode()
+	case err != nil:
+		chk.Error(
+			errMsgHeader("exec", result.cmdLine),
+			strings.TrimPrefix(err.Error(), "exec: "),
+		)
+	default:
+		result.exitCode = 0
+	}
+
+	return result
+}
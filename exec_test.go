@@ -0,0 +1,191 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func tstChkExec(t *testing.T) {
+	t.Run("Good", chkExecTestGood)
+	t.Run("WorkingDir", chkExecTestWorkingDir)
+	t.Run("Substitutions", chkExecTestSubstitutions)
+	t.Run("Mismatch", chkExecTestMismatch)
+	t.Run("NotFound", chkExecTestNotFound)
+	t.Run("Timeout", chkExecTestTimeout)
+}
+
+func execOutHelper(funcName string) string {
+	return tstOutHelper("(*ExecResult)." + funcName)
+}
+
+func chkExecTestGood(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.FakeCommand("szcli", CommandBehavior{
+		Stdout:   "line 1\nline 2\n",
+		Stderr:   "warning\n",
+		ExitCode: 2,
+	})
+
+	result := chk.Exec("szcli", "-v", "a file")
+	result.ExitCode(2)
+	result.Stdout("line 1", "line 2")
+	result.Stderr("warning")
+
+	chk.CommandCalls("szcli", `szcli -v "a file"`)
+
+	result = chk.Exec("true")
+	result.ExitCode(0)
+	result.Stdout()
+	result.Stderr()
+}
+
+func chkExecTestWorkingDir(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.Exec("pwd").Stdout(chk.CreateTmpDir())
+
+	subDir := chk.CreateTmpSubDir("sub")
+	chk.ExecIn(subDir, "pwd").Stdout(subDir)
+}
+
+func chkExecTestSubstitutions(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.AddSub(`\d{4}-\d{2}-\d{2}`, "{{date}}")
+
+	chk.FakeCommand("szdate", CommandBehavior{Stdout: "today: 2026-10-19\n"})
+
+	chk.Exec("szdate").Stdout("today: {{date}}")
+}
+
+func chkExecTestMismatch(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	iChk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	result := iChk.ExecIn(dir, "sh", "-c", "echo out; echo err >&2; exit 3")
+	result.ExitCode(0)
+	result.Stdout("other")
+	result.Stderr("err")
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("ExecIn"),
+		execOutHelper("ExitCode"),
+		chkOutHelper("errGotWnt"),
+		chkOutError(
+			chkOutCommonMsg(`sh -c "echo out; echo err >&2; exit 3"`, "exit code"),
+			g("3"),
+			w("0"),
+		),
+		execOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutError(
+			"Unexpected exec stdout Entry: got (1 lines) - want (1 lines)",
+			chkOutLnChanged("0", "0", "out", "other"),
+		),
+		execOutHelper("Stderr"),
+		chkOutHelper("compareLog"),
+		chkOutRelease(),
+	)
+}
+
+func chkExecTestNotFound(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	result := iChk.ExecIn(dir, "szDoesNotExist", "arg")
+	result.ExitCode(-1)
+	result.Stdout()
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("ExecIn"),
+		chkOutError(
+			chkOutCommonMsg("szDoesNotExist arg", "exec")+
+				"\n"+`"szDoesNotExist": executable file not found in $PATH`,
+		),
+		execOutHelper("ExitCode"),
+		execOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutRelease(),
+	)
+}
+
+func chkExecTestTimeout(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	origTimeout := settingExecTimeout
+	settingExecTimeout = 50 * time.Millisecond
+
+	defer func() {
+		settingExecTimeout = origTimeout
+	}()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	start := time.Now()
+	result := iChk.ExecIn(dir, "sleep", "10")
+	chk.DurBounded(time.Since(start), BoundedClosed, 0, 5*time.Second)
+
+	result.ExitCode(-1)
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("ExecIn"),
+		chkOutError(
+			chkOutCommonMsg("sleep 10", "exec")+"\ntimeout after 50ms",
+		),
+		execOutHelper("ExitCode"),
+		chkOutRelease(),
+	)
+
+	_, err := os.Stat(dir)
+	chk.NoErr(err)
+}
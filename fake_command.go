@@ -0,0 +1,307 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	fakeBinDirName = "fakebin"
+	fakeLogSuffix  = ".calls"
+)
+
+// CommandBehavior describes what a fake command created by FakeCommand does
+// when run: Stdout and Stderr are written verbatim, then the optional shell
+// Script lines are run and finally the command exits with ExitCode.
+type CommandBehavior struct {
+	Stdout   string
+	Stderr   string
+	Script   []string
+	ExitCode int
+}
+
+// CommandCall is a single recorded invocation of a fake command.
+type CommandCall struct {
+	Args  []string
+	Stdin string
+	Env   map[string]string
+}
+
+// String renders the call as a shell like command line with arguments
+// quoted only when necessary.
+func (c CommandCall) String() string {
+	parts := make([]string, len(c.Args))
+
+	for i, arg := range c.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`") ||
+			strconv.Quote(arg) != `"`+arg+`"` {
+			arg = strconv.Quote(arg)
+		}
+
+		parts[i] = arg
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fakeCommandScript returns the lines of a shell script recording each
+// invocation (hex encoded to survive any content) to logPath before acting
+// out the behavior. Output is copied from the outPath and errPath files so
+// that it is reproduced byte for byte.
+func fakeCommandScript(
+	name, logPath, outPath, errPath string, behavior CommandBehavior,
+) []string {
+	lines := []string{
+		"#!/bin/sh",
+		"fakeHex() { od -An -tx1 -v | tr -d ' \\n'; }",
+		"record=\"call $(printf '%s' " + shellQuote(name) + " | fakeHex)\"",
+		"for arg in \"$@\"; do",
+		"  record=\"$record",
+		"arg $(printf '%s' \"$arg\" | fakeHex)\"",
+		"done",
+		"record=\"$record",
+		"stdin $(fakeHex)",
+		"env $(env | fakeHex)",
+		"end\"",
+		"printf '%s\\n' \"$record\" >> " + shellQuote(logPath),
+	}
+
+	if behavior.Stdout != "" {
+		lines = append(lines, "cat "+shellQuote(outPath))
+	}
+
+	if behavior.Stderr != "" {
+		lines = append(lines, "cat "+shellQuote(errPath)+" >&2")
+	}
+
+	lines = append(lines, behavior.Script...)
+
+	return append(lines, fmt.Sprint("exit ", behavior.ExitCode))
+}
+
+// FakeCommand creates an executable script called name in a temporary bin
+// directory, which is prepended to PATH (via SetEnv) the first time it is
+// called, so that code under test running name through os/exec finds the
+// fake. Every invocation records its arguments, standard input (read until
+// EOF) and environment for later inspection with CommandCalls or
+// FakeCommandCalls before acting as described by behavior. Defining name
+// again replaces the fake and discards the calls recorded so far. The
+// calls log is created empty, with the file permission set by
+// SetPermFile, as soon as the fake is defined. The absolute path of the
+// script is returned. Fake commands are shell scripts and require a Unix
+// system.
+func (chk *Chk) FakeCommand(name string, behavior CommandBehavior) string {
+	chk.t.Helper()
+
+	if name == "" || strings.ContainsRune(name, '/') ||
+		strings.ContainsRune(name, filepath.Separator) {
+		chk.Error("invalid fake command name: ", strconv.Quote(name))
+
+		return ""
+	}
+
+	if chk.fakeBinDir == "" {
+		chk.fakeBinDir = chk.CreateTmpSubDir(fakeBinDirName)
+		chk.SetEnv(
+			"PATH",
+			chk.fakeBinDir+string(os.PathListSeparator)+os.Getenv("PATH"),
+		)
+	}
+
+	logPath := chk.fakeCommandFile(name, fakeLogSuffix)
+	outPath := chk.fakeCommandFile(name, ".stdout")
+	errPath := chk.fakeCommandFile(name, ".stderr")
+
+	err := removeTestFile(logPath)
+
+	if err == nil {
+		err = os.WriteFile(logPath, nil, settingPermFile)
+	}
+
+	if err == nil {
+		err = os.WriteFile(outPath, []byte(behavior.Stdout), settingPermFile)
+	}
+
+	if err == nil {
+		err = os.WriteFile(errPath, []byte(behavior.Stderr), settingPermFile)
+	}
+
+	if err != nil {
+		chk.Error("fakeCommand cause: ", err)
+
+		return ""
+	}
+
+	return chk.CreateTmpUnixScriptAs(
+		chk.fakeBinDir,
+		name,
+		fakeCommandScript(name, logPath, outPath, errPath, behavior),
+	)
+}
+
+// fakeCommandFile returns the path of a hidden support file for the fake
+// command name kept alongside the script.
+func (chk *Chk) fakeCommandFile(name, suffix string) string {
+	return filepath.Join(chk.fakeBinDir, "."+name+suffix)
+}
+
+func decodeFakeHex(field, value string) (string, error) {
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid fake command %s: %w", field, err)
+	}
+
+	return string(data), nil
+}
+
+// parseFakeEnv splits the output of env into variables. Lines without an
+// "=" continue the value of the previous variable.
+func parseFakeEnv(data string) map[string]string {
+	env := make(map[string]string)
+	last := ""
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(data, "\n"), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if !found && last != "" {
+			env[last] += "\n" + line
+
+			continue
+		}
+
+		env[key] = value
+		last = key
+	}
+
+	return env
+}
+
+//nolint:cyclop // Ok.
+func parseFakeCommandLog(data string) ([]CommandCall, error) {
+	var (
+		calls   []CommandCall
+		current *CommandCall
+		err     error
+	)
+
+	for line := range strings.SplitSeq(data, "\n") {
+		field, value, _ := strings.Cut(line, " ")
+
+		var decoded string
+
+		if field != "end" && field != "" {
+			decoded, err = decodeFakeHex(field, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case field == "call":
+			current = &CommandCall{Args: []string{decoded}}
+		case current == nil:
+			continue
+		case field == "arg":
+			current.Args = append(current.Args, decoded)
+		case field == "stdin":
+			current.Stdin = decoded
+		case field == "env":
+			current.Env = parseFakeEnv(decoded)
+		case field == "end":
+			calls = append(calls, *current)
+			current = nil
+		}
+	}
+
+	return calls, nil
+}
+
+// FakeCommandCalls returns every recorded invocation of the fake command
+// name in the order they completed. Args[0] holds the command name.
+func (chk *Chk) FakeCommandCalls(name string) []CommandCall {
+	chk.t.Helper()
+
+	if chk.fakeBinDir == "" {
+		chk.Error("unknown fake command: ", strconv.Quote(name))
+
+		return nil
+	}
+
+	data, err := os.ReadFile(chk.fakeCommandFile(name, fakeLogSuffix))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	var calls []CommandCall
+
+	if err == nil {
+		calls, err = parseFakeCommandLog(string(data))
+	}
+
+	if err != nil {
+		chk.Error("fakeCommand cause: ", err)
+	}
+
+	return calls
+}
+
+// CommandCalls compares the recorded invocations of the fake command name
+// against want, one command line per call formatted as by
+// CommandCall.String (for example `git commit -m "a message"`), reporting
+// any difference with the standard slice diff. Substitutions are applied
+// to both sides.
+func (chk *Chk) CommandCalls(name string, want ...string) bool {
+	chk.t.Helper()
+
+	calls := chk.FakeCommandCalls(name)
+	got := make([]string, len(calls))
+
+	for i, call := range calls {
+		got[i] = call.String()
+	}
+
+	ret := compareSlices(
+		"Unexpected Command Calls "+name,
+		chk.prepareSlice(keepLine, got...),
+		chk.prepareSlice(keepLine, want...),
+		settingDiffSlice,
+		settingDiffChars,
+		defaultCmpFunc[string],
+		chk.isStringify,
+	)
+
+	if ret != "" {
+		chk.Error(ret)
+
+		return false
+	}
+
+	return true
+}
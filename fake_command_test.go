@@ -0,0 +1,269 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tstChkFakeCommand(t *testing.T) {
+	t.Run("Run", chkFakeCommandTestRun)
+	t.Run("Calls", chkFakeCommandTestCalls)
+	t.Run("Redefine", chkFakeCommandTestRedefine)
+	t.Run("Log", chkFakeCommandTestLog)
+	t.Run("Script", chkFakeCommandTestScript)
+	t.Run("String", chkFakeCommandTestString)
+	t.Run("Mismatch", chkFakeCommandTestMismatch)
+}
+
+func runFakeCommand(
+	stdin string, env []string, name string, args ...string,
+) (string, string, int, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), env...)
+
+	err := cmd.Run()
+
+	exitErr := new(exec.ExitError)
+	if errors.As(err, &exitErr) {
+		return stdout.String(), stderr.String(), exitErr.ExitCode(), nil
+	}
+
+	return stdout.String(), stderr.String(), 0, err
+}
+
+func chkFakeCommandTestRun(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	origPath := os.Getenv("PATH")
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	script := iChk.FakeCommand("szfake", CommandBehavior{
+		Stdout:   "  padded output  \n",
+		Stderr:   "it's an error\n",
+		ExitCode: 3,
+	})
+	chk.Str(script, filepath.Join(iChk.CreateTmpDir(), "fakebin", "szfake"))
+	chk.True(strings.HasPrefix(os.Getenv("PATH"), filepath.Dir(script)))
+
+	stdout, stderr, code, err := runFakeCommand("", nil, "szfake")
+	chk.NoErr(err)
+	chk.Str(stdout, "  padded output  \n")
+	chk.Str(stderr, "it's an error\n")
+	chk.Int(code, 3)
+
+	iChk.Release()
+
+	chk.Str(os.Getenv("PATH"), origPath)
+	chk.FileNotExists(script)
+}
+
+func chkFakeCommandTestCalls(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.FakeCommand("szgit", CommandBehavior{})
+
+	chk.CommandCalls("szgit")
+
+	_, _, _, err := runFakeCommand("", nil, "szgit", "status")
+	chk.NoErr(err)
+
+	_, _, _, err = runFakeCommand(
+		"line one\nline two\n",
+		[]string{"SZ_FAKE_VAR=multi\nline"},
+		"szgit", "commit", "-m", "a 'quoted' message", "",
+	)
+	chk.NoErr(err)
+
+	chk.CommandCalls("szgit",
+		"szgit status",
+		`szgit commit -m "a 'quoted' message" ""`,
+	)
+
+	calls := chk.FakeCommandCalls("szgit")
+	chk.Int(len(calls), 2)
+	chk.StrSlice(calls[1].Args, []string{
+		"szgit", "commit", "-m", "a 'quoted' message", "",
+	})
+	chk.Str(calls[0].Stdin, "")
+	chk.Str(calls[1].Stdin, "line one\nline two\n")
+	chk.Str(calls[1].Env["SZ_FAKE_VAR"], "multi\nline")
+	chk.Str(calls[1].Env["PATH"], os.Getenv("PATH"))
+}
+
+func chkFakeCommandTestRedefine(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.FakeCommand("szone", CommandBehavior{Stdout: "first"})
+	chk.FakeCommand("sztwo", CommandBehavior{})
+
+	_, _, _, err := runFakeCommand("", nil, "szone", "a")
+	chk.NoErr(err)
+
+	chk.FakeCommand("szone", CommandBehavior{Stdout: "second"})
+
+	stdout, _, _, err := runFakeCommand("", nil, "szone", "b")
+	chk.NoErr(err)
+	chk.Str(stdout, "second")
+
+	chk.CommandCalls("szone", "szone b")
+	chk.CommandCalls("sztwo")
+}
+
+func chkFakeCommandTestLog(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermFile(chk.SetPermFile(0o640))
+
+	path := chk.FakeCommand("szlog", CommandBehavior{})
+
+	info, err := os.Stat(filepath.Join(filepath.Dir(path), ".szlog.calls"))
+	chk.NoErr(err)
+	chk.Str(info.Mode().String(), "-rw-r-----")
+	chk.Int64(info.Size(), 0)
+
+	chk.Int(len(chk.FakeCommandCalls("szlog")), 0)
+
+	_, _, _, err = runFakeCommand("", nil, "szlog", "a")
+	chk.NoErr(err)
+
+	info, err = os.Stat(filepath.Join(filepath.Dir(path), ".szlog.calls"))
+	chk.NoErr(err)
+	chk.Str(info.Mode().String(), "-rw-r-----")
+
+	chk.CommandCalls("szlog", "szlog a")
+}
+
+func chkFakeCommandTestScript(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.FakeCommand("szscript", CommandBehavior{
+		Stdout: "start\n",
+		Script: []string{
+			`echo "args: $*"`,
+			`[ "$1" = "fail" ] && exit 7`,
+		},
+	})
+
+	stdout, _, code, err := runFakeCommand("", nil, "szscript", "x", "y")
+	chk.NoErr(err)
+	chk.Str(stdout, "start\nargs: x y\n")
+	chk.Int(code, 0)
+
+	_, _, code, err = runFakeCommand("", nil, "szscript", "fail")
+	chk.NoErr(err)
+	chk.Int(code, 7)
+
+	chk.CommandCalls("szscript", "szscript x y", "szscript fail")
+}
+
+func chkFakeCommandTestString(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.Str(CommandCall{Args: []string{"cmd"}}.String(), "cmd")
+	chk.Str(
+		CommandCall{Args: []string{"cmd", "-a", "b c", "", "$x", "\x01"}}.
+			String(),
+		`cmd -a "b c" "" "$x" "\x01"`,
+	)
+}
+
+func chkFakeCommandTestMismatch(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	iT := new(iTst)
+	iChk := CaptureNothing(iT)
+	iT.chk = iChk
+
+	defer chk.SetPermDir(chk.SetPermDir(0o700))
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+	defer chk.SetPermExe(chk.SetPermExe(0o700))
+
+	tmpDir := iChk.CreateTmpDir()
+
+	defer func() {
+		chk.NoErr(os.RemoveAll(tmpDir))
+	}()
+
+	iChk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	iChk.FakeCommand("szmiss", CommandBehavior{})
+
+	_, _, _, err := runFakeCommand("", nil, "szmiss", "one")
+	chk.NoErr(err)
+
+	iChk.CommandCalls("szmiss", "szmiss two")
+	iChk.FakeCommand("", CommandBehavior{})
+
+	iChk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("CreateTmpDir"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("FakeCommand"),
+		chkOutHelper("SetEnv"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("CreateTmpUnixScriptAs"),
+		chkOutHelper("createFile"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("CommandCalls"),
+		chkOutHelper("FakeCommandCalls"),
+		chkOutError(
+			"Unexpected Command Calls szmiss: got (1 lines) - want (1 lines)",
+			markAsChg("0", "", diffGot)+":"+markAsChg("", "0", diffWant)+
+				" szmiss "+markAsChg("one", "two", diffMerge),
+		),
+		chkOutHelper("FakeCommand"),
+		chkOutError(`invalid fake command name: ""`),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutPush("Pre", "func2"),
+		chkOutPush("Pre", "func1"),
+		chkOutTmpSummary(tmpDir,
+			"drwx------ fakebin/",
+			"-rw------- fakebin/.szmiss.calls",
+			"-rw------- fakebin/.szmiss.stderr",
+			"-rw------- fakebin/.szmiss.stdout",
+			"-rwx------ fakebin/szmiss",
+		),
+	)
+}
@@ -0,0 +1,522 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing/fstest"
+)
+
+// FSOp identifies a filesystem operation targeted by FakeFS.SetError.
+type FSOp int
+
+// Filesystem operations that may be failed with FakeFS.SetError.
+const (
+	FSOpen FSOp = iota
+	FSRead
+	FSStat
+	FSReadDir
+	FSWriteFile
+	FSMkdir
+	FSRemove
+)
+
+// String returns the operation name used in the *fs.PathError returned for
+// an injected fault.
+func (op FSOp) String() string {
+	switch op {
+	case FSOpen:
+		return "open"
+	case FSRead:
+		return "read"
+	case FSStat:
+		return "stat"
+	case FSReadDir:
+		return "readdir"
+	case FSWriteFile:
+		return "write"
+	case FSMkdir:
+		return "mkdir"
+	case FSRemove:
+		return "remove"
+	default:
+		return fmt.Sprint("FSOp(", int(op), ")")
+	}
+}
+
+// WritableFS is the minimal writable filesystem implemented by FakeFS. Code
+// that needs to create or delete files can accept a WritableFS in place of
+// the real os package functions.
+type WritableFS interface {
+	fs.FS
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	Remove(name string) error
+}
+
+type fsFaultKey struct {
+	op   FSOp
+	path string
+}
+
+type fsFault struct {
+	err error
+	hit bool
+}
+
+// FakeFS is an in-memory filesystem built on fstest.MapFS implementing
+// fs.FS, fs.StatFS, fs.ReadFileFS, fs.ReadDirFS and WritableFS. Errors may
+// be injected for specific operations on specific paths with SetError so
+// that permission and I/O failures can be tested without touching the disk.
+// Create instances with chk.NewFakeFS or chk.NewFakeFSFromTxtar. All
+// methods are safe for concurrent use.
+type FakeFS struct {
+	mu         sync.Mutex
+	name       string
+	files      fstest.MapFS
+	faults     map[fsFaultKey]*fsFault
+	faultOrder []fsFaultKey
+}
+
+// Name returns the identifier used for the fake in failure messages.
+func (f *FakeFS) Name() string {
+	return f.name
+}
+
+// SetError primes the fake to fail every op on the named path with an
+// *fs.PathError wrapping err until cleared by calling SetError again with a
+// nil err. FSRead and FSReadDir apply to files opened from the fake as well
+// as to ReadFile and ReadDir. An injected error never triggered before
+// chk.Release() is reported as a failure.
+func (f *FakeFS) SetError(op FSOp, name string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := fsFaultKey{op: op, path: name}
+
+	if err == nil {
+		delete(f.faults, key)
+
+		return
+	}
+
+	if _, ok := f.faults[key]; !ok {
+		f.faultOrder = append(f.faultOrder, key)
+	}
+
+	f.faults[key] = &fsFault{err: err}
+}
+
+// fault returns the injected error for op on name, if any. The caller must
+// hold f.mu.
+func (f *FakeFS) fault(op FSOp, name string) error {
+	fault, ok := f.faults[fsFaultKey{op: op, path: name}]
+	if !ok {
+		return nil
+	}
+
+	fault.hit = true
+
+	return &fs.PathError{Op: op.String(), Path: name, Err: fault.err}
+}
+
+func (f *FakeFS) lockedFault(op FSOp, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.fault(op, name)
+}
+
+// unusedFaults lists the injected errors never returned to the code under
+// test.
+func (f *FakeFS) unusedFaults() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var faults []string
+
+	for _, key := range f.faultOrder {
+		fault, ok := f.faults[key]
+		if ok && !fault.hit {
+			faults = append(faults, fmt.Sprint(
+				"SetError(", key.op, ", ", key.path, ", ", fault.err, ")",
+			))
+		}
+	}
+
+	return faults
+}
+
+// Open implements fs.FS. The returned file honors FSRead, FSStat and
+// FSReadDir faults set for the same name.
+func (f *FakeFS) Open(name string) (fs.File, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fault(FSOpen, name); err != nil {
+		return nil, err
+	}
+
+	file, err := f.files.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Already an *fs.PathError.
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return nil, err //nolint:wrapcheck // Already an *fs.PathError.
+	}
+
+	if info.IsDir() {
+		return &fakeFSDir{fakeFSFile{File: file, fsys: f, name: name}}, nil
+	}
+
+	return &fakeFSFile{File: file, fsys: f, name: name}, nil
+}
+
+// Stat implements fs.StatFS.
+func (f *FakeFS) Stat(name string) (fs.FileInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fault(FSStat, name); err != nil {
+		return nil, err
+	}
+
+	return f.files.Stat(name) //nolint:wrapcheck // Already an *fs.PathError.
+}
+
+// ReadFile implements fs.ReadFileFS honoring both FSOpen and FSRead faults.
+func (f *FakeFS) ReadFile(name string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fault(FSOpen, name); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(FSRead, name); err != nil {
+		return nil, err
+	}
+
+	return f.files.ReadFile(name) //nolint:wrapcheck // Already *fs.PathError.
+}
+
+// ReadDir implements fs.ReadDirFS honoring both FSOpen and FSReadDir faults.
+func (f *FakeFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fault(FSOpen, name); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(FSReadDir, name); err != nil {
+		return nil, err
+	}
+
+	return f.files.ReadDir(name) //nolint:wrapcheck // Already *fs.PathError.
+}
+
+// WriteFile creates or truncates the named file. As with os.WriteFile the
+// parent directory must already exist and an existing file keeps its
+// permissions.
+func (f *FakeFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	const op = "write"
+
+	if err := f.fault(FSWriteFile, name); err != nil {
+		return err
+	}
+
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	parent, ok := f.files[path.Dir(name)]
+	if path.Dir(name) != "." && (!ok || !parent.Mode.IsDir()) {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	if existing, ok := f.files[name]; ok {
+		if existing.Mode.IsDir() {
+			return &fs.PathError{Op: op, Path: name, Err: ErrInvalidFile}
+		}
+
+		perm = existing.Mode
+	}
+
+	f.files[name] = &fstest.MapFile{
+		Data: append([]byte(nil), data...),
+		Mode: perm.Perm(),
+	}
+
+	return nil
+}
+
+// MkdirAll creates the named directory along with any missing parents.
+func (f *FakeFS) MkdirAll(name string, perm fs.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fault(FSMkdir, name); err != nil {
+		return err
+	}
+
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return f.mkdirAll(name, perm)
+}
+
+// mkdirAll adds explicit directory entries for name and its parents. The
+// caller must hold f.mu.
+func (f *FakeFS) mkdirAll(name string, perm fs.FileMode) error {
+	if name == "." {
+		return nil
+	}
+
+	if err := f.mkdirAll(path.Dir(name), perm); err != nil {
+		return err
+	}
+
+	existing, ok := f.files[name]
+	if !ok {
+		f.files[name] = &fstest.MapFile{Mode: fs.ModeDir | perm.Perm()}
+
+		return nil
+	}
+
+	if !existing.Mode.IsDir() {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: ErrInvalidDirectory}
+	}
+
+	return nil
+}
+
+// Remove deletes the named file or empty directory.
+func (f *FakeFS) Remove(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	const op = "remove"
+
+	if err := f.fault(FSRemove, name); err != nil {
+		return err
+	}
+
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	existing, ok := f.files[name]
+	if !ok {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	if existing.Mode.IsDir() {
+		for child := range f.files {
+			if strings.HasPrefix(child, name+"/") {
+				return &fs.PathError{Op: op, Path: name, Err: ErrDirNotEmpty}
+			}
+		}
+	}
+
+	delete(f.files, name)
+
+	return nil
+}
+
+// fakeFSFile wraps a file opened from a FakeFS to apply injected faults.
+type fakeFSFile struct {
+	fs.File
+
+	fsys *FakeFS
+	name string
+}
+
+func (f *fakeFSFile) Read(buf []byte) (int, error) {
+	if err := f.fsys.lockedFault(FSRead, f.name); err != nil {
+		return 0, err
+	}
+
+	return f.File.Read(buf) //nolint:wrapcheck // Pass through.
+}
+
+func (f *fakeFSFile) Stat() (fs.FileInfo, error) {
+	if err := f.fsys.lockedFault(FSStat, f.name); err != nil {
+		return nil, err
+	}
+
+	return f.File.Stat() //nolint:wrapcheck // Pass through.
+}
+
+func (f *fakeFSFile) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := f.File.(io.Seeker)
+	if !ok {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+
+	return seeker.Seek(offset, whence) //nolint:wrapcheck // Pass through.
+}
+
+func (f *fakeFSFile) ReadAt(buf []byte, offset int64) (int, error) {
+	if err := f.fsys.lockedFault(FSRead, f.name); err != nil {
+		return 0, err
+	}
+
+	readerAt, ok := f.File.(io.ReaderAt)
+	if !ok {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+	}
+
+	return readerAt.ReadAt(buf, offset) //nolint:wrapcheck // Pass through.
+}
+
+// fakeFSDir wraps a directory opened from a FakeFS.
+type fakeFSDir struct {
+	fakeFSFile
+}
+
+func (d *fakeFSDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	if err := d.fsys.lockedFault(FSReadDir, d.name); err != nil {
+		return nil, err
+	}
+
+	dir, ok := d.File.(fs.ReadDirFile)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: fs.ErrInvalid}
+	}
+
+	return dir.ReadDir(count) //nolint:wrapcheck // Pass through.
+}
+
+// newFakeFS registers a new empty FakeFS reporting its unused faults when
+// the test is released.
+func (chk *Chk) newFakeFS() *FakeFS {
+	chk.t.Helper()
+
+	chk.nextFakeID++
+	fake := &FakeFS{
+		name:   fmt.Sprint("FakeFS#", chk.nextFakeID),
+		files:  make(fstest.MapFS),
+		faults: make(map[fsFaultKey]*fsFault),
+	}
+
+	chk.PushPreReleaseFunc(func() error {
+		chk.t.Helper()
+
+		if chk.faultCount == 0 {
+			for _, fault := range fake.unusedFaults() {
+				chk.Error(fake.name, ": injected fault never triggered: ", fault)
+			}
+		}
+
+		return nil
+	})
+
+	return fake
+}
+
+// seedFakeFS adds a file (or a directory if name ends in "/") along with
+// explicit entries for all of its parent directories.
+func (chk *Chk) seedFakeFS(fake *FakeFS, name string, data []byte) {
+	chk.t.Helper()
+
+	dirName := strings.TrimSuffix(name, "/")
+	isDir := dirName != name
+
+	if !fs.ValidPath(dirName) || dirName == "." {
+		chk.Error("invalid fake filesystem path: ", name)
+
+		return
+	}
+
+	parent := dirName
+	if !isDir {
+		parent = path.Dir(dirName)
+	}
+
+	err := fake.mkdirAll(parent, settingPermDir)
+	if err == nil && !isDir {
+		if existing, ok := fake.files[dirName]; ok && existing.Mode.IsDir() {
+			err = &fs.PathError{Op: "write", Path: name, Err: ErrInvalidFile}
+		} else {
+			fake.files[dirName] = &fstest.MapFile{
+				Data: data,
+				Mode: settingPermFile,
+			}
+		}
+	}
+
+	if err != nil {
+		chk.Error("fake filesystem seed cause: ", err)
+	}
+}
+
+// NewFakeFS returns an in-memory filesystem seeded with files mapping slash
+// separated relative paths to their contents. A path ending in "/" creates
+// an empty directory. Parent directories are created automatically using
+// the current directory mode setting while files use the current file mode
+// setting.
+func (chk *Chk) NewFakeFS(files map[string]string) *FakeFS {
+	chk.t.Helper()
+
+	fake := chk.newFakeFS()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		chk.seedFakeFS(fake, name, []byte(files[name]))
+	}
+
+	return fake
+}
+
+// NewFakeFSFromTxtar returns an in-memory filesystem seeded from a txtar
+// archive (see golang.org/x/tools/txtar). Each "-- name --" section becomes
+// a file; a name ending in "/" creates an empty directory. The archive
+// comment is ignored.
+func (chk *Chk) NewFakeFSFromTxtar(archive string) *FakeFS {
+	chk.t.Helper()
+
+	fake := chk.newFakeFS()
+
+	for _, file := range parseTxtar([]byte(archive)).files {
+		chk.seedFakeFS(fake, file.name, file.data)
+	}
+
+	return fake
+}
@@ -0,0 +1,329 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func tstChkFsFake(t *testing.T) {
+	t.Run("TestFS", chkFsFakeTestTestFS)
+	t.Run("Txtar", chkFsFakeTestTxtar)
+	t.Run("Write", chkFsFakeTestWrite)
+	t.Run("WriteInvalid", chkFsFakeTestWriteInvalid)
+	t.Run("Faults", chkFsFakeTestFaults)
+	t.Run("FileFaults", chkFsFakeTestFileFaults)
+	t.Run("ClearFault", chkFsFakeTestClearFault)
+	t.Run("UnusedFaults", chkFsFakeTestUnusedFaults)
+	t.Run("InvalidSeed", chkFsFakeTestInvalidSeed)
+}
+
+func chkFsFakeTestTestFS(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fsys := chk.NewFakeFS(map[string]string{
+		"a.txt":         "file a\n",
+		"dir/b.txt":     "file b\n",
+		"dir/sub/c.txt": "file c\n",
+		"empty/":        "",
+	})
+
+	chk.NoErr(fstest.TestFS(fsys, "a.txt", "dir/b.txt", "dir/sub/c.txt"))
+
+	info, err := fs.Stat(fsys, "empty")
+	chk.NoErr(err)
+	chk.True(info.IsDir())
+
+	chk.Str(fsys.Name(), "FakeFS#1")
+}
+
+func chkFsFakeTestTxtar(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fsys := chk.NewFakeFSFromTxtar("" +
+		"Ignored comment.\n" +
+		"-- a.txt --\n" +
+		"file a\n" +
+		"-- dir/b.txt --\n" +
+		"file b\n" +
+		"-- empty/ --\n",
+	)
+
+	data, err := fs.ReadFile(fsys, "dir/b.txt")
+	chk.NoErr(err)
+	chk.Str(string(data), "file b\n")
+
+	entries, err := fs.ReadDir(fsys, ".")
+	chk.NoErr(err)
+	chk.Int(len(entries), 3)
+	chk.Str(entries[0].Name(), "a.txt")
+	chk.Str(entries[1].Name(), "dir")
+	chk.Str(entries[2].Name(), "empty")
+}
+
+func chkFsFakeTestWrite(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	defer chk.SetPermFile(chk.SetPermFile(0o600))
+
+	var fsys WritableFS = chk.NewFakeFS(map[string]string{
+		"dir/old.txt": "old",
+	})
+
+	chk.NoErr(fsys.MkdirAll("a/b/c", 0o750))
+	chk.NoErr(fsys.WriteFile("a/b/c/new.txt", []byte("new"), 0o640))
+	chk.NoErr(fsys.WriteFile("dir/old.txt", []byte("replaced"), 0o777))
+
+	data, err := fs.ReadFile(fsys, "a/b/c/new.txt")
+	chk.NoErr(err)
+	chk.Str(string(data), "new")
+
+	info, err := fs.Stat(fsys, "a/b")
+	chk.NoErr(err)
+	chk.True(info.IsDir())
+	chk.Str(info.Mode().Perm().String(), "-rwxr-x---")
+
+	info, err = fs.Stat(fsys, "dir/old.txt")
+	chk.NoErr(err)
+	chk.Int64(info.Size(), 8)
+	chk.Str(info.Mode().String(), "-rw-------")
+
+	chk.NoErr(fsys.Remove("dir/old.txt"))
+
+	_, err = fs.Stat(fsys, "dir/old.txt")
+	chk.True(errors.Is(err, fs.ErrNotExist))
+
+	info, err = fs.Stat(fsys, "dir")
+	chk.NoErr(err)
+	chk.True(info.IsDir())
+
+	chk.NoErr(fsys.Remove("dir"))
+
+	_, err = fs.Stat(fsys, "dir")
+	chk.True(errors.Is(err, fs.ErrNotExist))
+}
+
+func chkFsFakeTestWriteInvalid(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fsys := chk.NewFakeFS(map[string]string{
+		"dir/file.txt": "data",
+	})
+
+	err := fsys.WriteFile("missing/file.txt", nil, 0o600)
+	chk.Err(err, "write missing/file.txt: file does not exist")
+
+	err = fsys.WriteFile("dir", nil, 0o600)
+	chk.Err(err, "write dir: "+ErrInvalidFile.Error())
+
+	err = fsys.WriteFile("../escape", nil, 0o600)
+	chk.Err(err, "write ../escape: invalid argument")
+
+	err = fsys.MkdirAll("dir/file.txt/sub", 0o700)
+	chk.Err(err, "mkdir dir/file.txt: "+ErrInvalidDirectory.Error())
+
+	err = fsys.Remove("dir")
+	chk.Err(err, "remove dir: "+ErrDirNotEmpty.Error())
+	chk.True(errors.Is(err, ErrDirNotEmpty))
+
+	err = fsys.Remove("missing")
+	chk.Err(err, "remove missing: file does not exist")
+
+	err = fsys.Remove(".")
+	chk.Err(err, "remove .: invalid argument")
+}
+
+func chkFsFakeTestFaults(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fsys := chk.NewFakeFS(map[string]string{
+		"a.txt":     "file a",
+		"dir/b.txt": "file b",
+	})
+
+	errDenied := fs.ErrPermission
+
+	fsys.SetError(FSOpen, "a.txt", errDenied)
+	fsys.SetError(FSStat, "dir/b.txt", errDenied)
+	fsys.SetError(FSReadDir, "dir", errDenied)
+	fsys.SetError(FSWriteFile, "new.txt", errDenied)
+	fsys.SetError(FSMkdir, "newDir", errDenied)
+	fsys.SetError(FSRemove, "dir/b.txt", errDenied)
+
+	_, err := fsys.Open("a.txt")
+	chk.Err(err, "open a.txt: permission denied")
+	chk.True(errors.Is(err, fs.ErrPermission))
+
+	var pathErr *fs.PathError
+
+	chk.True(errors.As(err, &pathErr))
+	chk.Str(pathErr.Op, "open")
+
+	_, err = fs.ReadFile(fsys, "a.txt")
+	chk.Err(err, "open a.txt: permission denied")
+
+	_, err = fs.Stat(fsys, "dir/b.txt")
+	chk.Err(err, "stat dir/b.txt: permission denied")
+
+	_, err = fs.ReadDir(fsys, "dir")
+	chk.Err(err, "readdir dir: permission denied")
+
+	err = fsys.WriteFile("new.txt", nil, 0o600)
+	chk.Err(err, "write new.txt: permission denied")
+
+	err = fsys.MkdirAll("newDir", 0o700)
+	chk.Err(err, "mkdir newDir: permission denied")
+
+	err = fsys.Remove("dir/b.txt")
+	chk.Err(err, "remove dir/b.txt: permission denied")
+
+	// Faults persist until cleared.
+	_, err = fsys.Open("a.txt")
+	chk.Err(err, "open a.txt: permission denied")
+}
+
+func chkFsFakeTestFileFaults(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fsys := chk.NewFakeFS(map[string]string{
+		"dir/b.txt": "file b",
+	})
+
+	errIO := errors.New("i/o error")
+
+	fsys.SetError(FSRead, "dir/b.txt", errIO)
+	fsys.SetError(FSReadDir, "dir", errIO)
+
+	file, err := fsys.Open("dir/b.txt")
+	chk.NoErr(err)
+
+	_, err = io.ReadAll(file)
+	chk.Err(err, "read dir/b.txt: i/o error")
+	chk.True(errors.Is(err, errIO))
+
+	_, err = file.(io.ReaderAt).ReadAt(make([]byte, 1), 0)
+	chk.Err(err, "read dir/b.txt: i/o error")
+
+	info, err := file.Stat()
+	chk.NoErr(err)
+	chk.Int64(info.Size(), 6)
+	chk.NoErr(file.Close())
+
+	dir, err := fsys.Open("dir")
+	chk.NoErr(err)
+
+	dirFile, ok := dir.(fs.ReadDirFile)
+	chk.True(ok)
+
+	_, err = dirFile.ReadDir(-1)
+	chk.Err(err, "readdir dir: i/o error")
+	chk.NoErr(dir.Close())
+}
+
+func chkFsFakeTestClearFault(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fsys := chk.NewFakeFS(map[string]string{
+		"a.txt": "file a",
+	})
+
+	fsys.SetError(FSRead, "a.txt", errors.New("first"))
+	fsys.SetError(FSRead, "a.txt", errors.New("second"))
+
+	_, err := fs.ReadFile(fsys, "a.txt")
+	chk.Err(err, "read a.txt: second")
+
+	fsys.SetError(FSRead, "a.txt", nil)
+	// Clearing an untriggered fault removes it from the unused report.
+	fsys.SetError(FSStat, "a.txt", errors.New("never"))
+	fsys.SetError(FSStat, "a.txt", nil)
+
+	data, err := fs.ReadFile(fsys, "a.txt")
+	chk.NoErr(err)
+	chk.Str(string(data), "file a")
+}
+
+func chkFsFakeTestUnusedFaults(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	orig := chk.FailFast(false)
+	defer chk.FailFast(orig)
+
+	fsys := chk.NewFakeFS(nil)
+	fsys.SetError(FSOpen, "a.txt", errors.New("never opened"))
+	fsys.SetError(FSRemove, "dir", fs.ErrPermission)
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("NewFakeFS"),
+		chkOutHelper("newFakeFS"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("newFakeFS.func1"),
+		chkOutErrorNoFail(
+			"FakeFS#1: injected fault never triggered: "+
+				"SetError(open, a.txt, never opened)",
+		),
+		chkOutErrorNoFail(
+			"FakeFS#1: injected fault never triggered: "+
+				"SetError(remove, dir, permission denied)",
+		),
+	)
+}
+
+func chkFsFakeTestInvalidSeed(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	orig := chk.FailFast(false)
+	defer chk.FailFast(orig)
+
+	chk.NewFakeFS(map[string]string{
+		"../escape": "data",
+	})
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("NewFakeFS"),
+		chkOutHelper("newFakeFS"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("seedFakeFS"),
+		chkOutErrorNoFail("invalid fake filesystem path: ../escape"),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("newFakeFS.func1"),
+	)
+}
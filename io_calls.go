@@ -0,0 +1,131 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CallLogger is implemented by the IO fakes (and chk itself) recording the
+// calls made on them.
+type CallLogger interface {
+	CallLog() []string
+}
+
+func whenceName(whence int) string {
+	switch whence {
+	case io.SeekStart:
+		return "start"
+	case io.SeekCurrent:
+		return "current"
+	case io.SeekEnd:
+		return "end"
+	}
+
+	return fmt.Sprint(whence)
+}
+
+// recordCall appends call to the log followed by its result (if not nil)
+// and error (if not nil).
+func (f *FakeIO) recordCall(call string, result any, err error) {
+	switch {
+	case result != nil && err != nil:
+		call += fmt.Sprint(" = ", result, ", ", err)
+	case result != nil:
+		call += fmt.Sprint(" = ", result)
+	case err != nil:
+		call += fmt.Sprint(" = ", err)
+	}
+
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	f.calls = append(f.calls, call)
+}
+
+// CallLog returns the calls made on the fake in order, formatted as for
+// example:
+//
+//	Read(4096) = 24
+//	Read(4096) = 0, EOF
+//	Write(5) = 5
+//	Seek(10, start) = 10
+//	ReadAt(4, 3) = 4
+//	Close()
+func (f *FakeIO) CallLog() []string {
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	return append([]string{}, f.calls...)
+}
+
+// CallCount returns the number of calls made to the method op (for
+// example "Read" or "Close").
+func (f *FakeIO) CallCount(op string) int {
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	count := 0
+
+	for _, call := range f.calls {
+		if strings.HasPrefix(call, op+"(") {
+			count++
+		}
+	}
+
+	return count
+}
+
+// CallLog returns the calls made on chk's own io methods as described for
+// FakeIO.CallLog.
+func (chk *Chk) CallLog() []string {
+	return chk.fakeIO.CallLog()
+}
+
+// CallCount returns the number of calls made to chk's own io method op.
+func (chk *Chk) CallCount(op string) int {
+	return chk.fakeIO.CallCount(op)
+}
+
+// IOCalls compares the calls recorded by src (a fake or chk itself)
+// against want reporting any difference with the standard slice diff.
+// Substitutions are applied to both sides.
+func (chk *Chk) IOCalls(src CallLogger, want ...string) bool {
+	chk.t.Helper()
+
+	ret := compareSlices(
+		"Unexpected IO Calls",
+		chk.prepareSlice(keepLine, src.CallLog()...),
+		chk.prepareSlice(keepLine, want...),
+		settingDiffSlice,
+		settingDiffChars,
+		defaultCmpFunc[string],
+		chk.isStringify,
+	)
+
+	if ret != "" {
+		chk.Error(ret)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,154 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func tstChkIoCalls(t *testing.T) {
+	t.Run("FakeIO", chkIoCallsTestFakeIO)
+	t.Run("Chk", chkIoCallsTestChk)
+	t.Run("Conn", chkIoCallsTestConn)
+	t.Run("Mismatch", chkIoCallsTestMismatch)
+}
+
+func chkIoCallsTestFakeIO(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeReadWriteCloser("0123456789")
+	fake.SetCloseError(errors.New("close failed"))
+
+	buf := make([]byte, 8)
+
+	_, _ = fake.Read(buf)
+	_, _ = fake.Read(buf)
+	_, _ = fake.Read(buf)
+	_, _ = fake.Seek(4, io.SeekStart)
+	_, _ = fake.Seek(-1, io.SeekCurrent)
+	_, _ = fake.Seek(-2, io.SeekEnd)
+	_, _ = fake.Seek(0, 7)
+	_, _ = fake.ReadAt(buf[:4], 3)
+	_, _ = fake.WriteAt([]byte("ab"), 1)
+	_, _ = fake.Write([]byte("hello"))
+	_ = fake.Close()
+	_ = fake.Close()
+
+	chk.IOCalls(fake,
+		"Read(8) = 8",
+		"Read(8) = 2",
+		"Read(8) = 0, EOF",
+		"Seek(4, start) = 4",
+		"Seek(-1, current) = 3",
+		"Seek(-2, end) = 8",
+		"Seek(0, 7) = 8, invalid seek: whence 7",
+		"ReadAt(4, 3) = 4",
+		"WriteAt(2, 1) = 2",
+		"Write(5) = 5",
+		"Close() = close failed",
+		"Close()",
+	)
+
+	chk.Int(fake.CallCount("Read"), 3)
+	chk.Int(fake.CallCount("ReadAt"), 1)
+	chk.Int(fake.CallCount("Close"), 2)
+	chk.Int(fake.CallCount("Flush"), 0)
+}
+
+func chkIoCallsTestChk(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.SetIOReaderData("abc")
+
+	_, _ = chk.Read(make([]byte, 2))
+	_, _ = chk.Write([]byte("x"))
+	_ = chk.Close()
+
+	chk.StrSlice(chk.CallLog(), []string{
+		"Read(2) = 2",
+		"Write(1) = 1",
+		"Close()",
+	})
+
+	chk.IOCalls(chk, "Read(2) = 2", "Write(1) = 1", "Close()")
+	chk.Int(chk.CallCount("Write"), 1)
+}
+
+func chkIoCallsTestConn(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	client, server := chk.NewFakeConnPair()
+
+	_, _ = client.Write([]byte("ping"))
+	_ = client.CloseWrite()
+	_, _ = server.Read(make([]byte, 16))
+	_, _ = server.Read(make([]byte, 16))
+	_ = server.CloseRead()
+	_ = server.Close()
+	_ = server.Close()
+
+	chk.IOCalls(client, "Write(4) = 4", "CloseWrite()")
+	chk.IOCalls(server,
+		"Read(16) = 4",
+		"Read(16) = 0, EOF",
+		"CloseRead()",
+		"Close()",
+		"Close() = use of closed network connection",
+	)
+}
+
+func chkIoCallsTestMismatch(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.markupForDisplay = func(s string) string {
+		return s
+	}
+
+	fake := chk.NewFakeWriter()
+
+	_, _ = fake.Write([]byte("abc"))
+	_ = fake.Close()
+
+	chk.IOCalls(fake, "Write(3) = 3")
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("NewFakeWriter"),
+		chkOutHelper("newFake"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("IOCalls"),
+		chkOutError(
+			"Unexpected IO Calls: got (2 lines) - want (1 lines)",
+			chkOutLnSame("0", "0", "Write(3) = 3"),
+			chkOutLnGot("1", "Close()"),
+		),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("newFake.func1"),
+		chkOutHelper("reportUnused"),
+	)
+}
@@ -18,12 +18,46 @@
 
 package sztest
 
+// SetCloseError primes the fake so that the next call to Close returns
+// err. After returning err once, Close resets to return nil on subsequent
+// calls unless SetCloseError is invoked again.
+func (f *FakeIO) SetCloseError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ioCloseErr = err
+	f.ioCloseErrSet = true
+}
+
+// Close implements io.Closer. It returns the error previously set by
+// SetCloseError, or nil if no error is pending.
+func (f *FakeIO) Close() error {
+	err := f.close()
+	f.observeClose()
+	f.recordCall("Close()", nil, err)
+
+	return err
+}
+
+func (f *FakeIO) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	closeErr := f.ioCloseErr
+
+	if f.ioCloseErrSet {
+		f.ioCloseErr = nil
+		f.ioCloseErrSet = false
+	}
+
+	return closeErr
+}
+
 // SetCloseError primes chk so that the next call to Close returns err.
 // After returning err once, Close resets to return nil on subsequent calls
 // unless SetCloseError is invoked again.
 func (chk *Chk) SetCloseError(err error) {
-	chk.ioCloseErr = err
-	chk.ioCloseErrSet = true
+	chk.fakeIO.SetCloseError(err)
 }
 
 // Close implements io.Closer for chk. It returns the error previously set
@@ -31,12 +65,5 @@ func (chk *Chk) SetCloseError(err error) {
 // non-nil error, Close resets to return nil on future calls until
 // re-primed.
 func (chk *Chk) Close() error {
-	closeErr := chk.ioCloseErr
-
-	if chk.ioCloseErrSet {
-		chk.ioCloseErr = nil
-		chk.ioCloseErrSet = false
-	}
-
-	return closeErr
+	return chk.fakeIO.Close()
 }
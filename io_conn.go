@@ -0,0 +1,688 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Maximum number of dialed connections waiting to be accepted by a
+// FakeListener.
+const fakeListenerBacklog = 128
+
+type fakeAddr string
+
+// Network implements net.Addr.
+func (a fakeAddr) Network() string {
+	return "fake"
+}
+
+// String implements net.Addr.
+func (a fakeAddr) String() string {
+	return string(a)
+}
+
+// connPipe carries data in one direction between the two ends of a fake
+// connection. Writes never block.
+type connPipe struct {
+	mu           sync.Mutex
+	data         []byte
+	writeClosed  bool
+	readerClosed bool
+	notify       chan struct{}
+}
+
+// wake releases any reader waiting on the pipe. The caller must hold the
+// lock.
+func (p *connPipe) wake() {
+	if p.notify != nil {
+		close(p.notify)
+		p.notify = nil
+	}
+}
+
+// FakeConn is an in-memory net.Conn created in connected pairs by
+// chk.NewFakeConnPair or FakeListener.Dial. Data written to one end is read
+// from the other. It offers the subset of the FakeIO error injection that
+// applies to a connection (SetReadError, SetIOReaderError, SetWriteError,
+// SetIOWriterError, SetReadSchedule, SetWriteSchedule and SetCloseError)
+// and records all data written, retrievable with GetIOWriterData. Data
+// is only ever supplied by the other end so there is no SetIOReaderData
+// and, as a stream, no Seek, ReadAt or WriteAt.
+//
+// Deadlines are measured against the chk test clock: a deadline at or
+// before the clock's next timestamp has already expired. Otherwise a
+// blocked Read waits (in real time) for the remaining difference before
+// failing with os.ErrDeadlineExceeded, re-evaluating the deadline whenever
+// the test clock moves (for example through pacing with the clock).
+type FakeConn struct {
+	fakeIO *FakeIO
+
+	in     *connPipe
+	out    *connPipe
+	local  net.Addr
+	remote net.Addr
+	now    func() time.Time
+	moved  func() <-chan struct{}
+
+	connMu        sync.Mutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+	closed        bool
+	closing       chan struct{}
+}
+
+func (chk *Chk) newFakeConn(
+	fake *FakeIO, in, out *connPipe, local, remote string,
+) *FakeConn {
+	return &FakeConn{
+		fakeIO:  fake,
+		in:      in,
+		out:     out,
+		local:   fakeAddr(local),
+		remote:  fakeAddr(remote),
+		now:     func() time.Time { return chk.clk.peek() },
+		moved:   func() <-chan struct{} { return chk.clk.watch() },
+		closing: make(chan struct{}),
+	}
+}
+
+// NewFakeConnPair returns the two connected ends of an in-memory network
+// connection. Like the other fakes any injected error or scheduled step
+// still pending when chk.Release() is called is reported as a failure.
+func (chk *Chk) NewFakeConnPair() (*FakeConn, *FakeConn) {
+	chk.t.Helper()
+
+	clientFake := chk.newFake("FakeConn")
+	serverFake := chk.newFake("FakeConn")
+
+	return chk.newFakeConnPair(
+		clientFake, serverFake, "fake-client", "fake-server",
+	)
+}
+
+func (chk *Chk) newFakeConnPair(
+	clientFake, serverFake *FakeIO, client, server string,
+) (*FakeConn, *FakeConn) {
+	toServer := new(connPipe)
+	toClient := new(connPipe)
+
+	return chk.newFakeConn(clientFake, toClient, toServer, client, server),
+		chk.newFakeConn(serverFake, toServer, toClient, server, client)
+}
+
+func (c *FakeConn) isClosed() bool {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	return c.closed
+}
+
+func (c *FakeConn) deadline(read bool) time.Time {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if read {
+		return c.readDeadline
+	}
+
+	return c.writeDeadline
+}
+
+// expired reports how long remains before the deadline (according to the
+// test clock) and whether it has already passed.
+func (c *FakeConn) expired(deadline time.Time) (time.Duration, bool) {
+	if deadline.IsZero() {
+		return 0, false
+	}
+
+	remaining := deadline.Sub(c.now())
+
+	return remaining, remaining <= 0
+}
+
+// Read implements net.Conn returning data written by the other end. It
+// blocks until data is available, the other end closes its writing side
+// (io.EOF), the connection is closed (net.ErrClosed) or the read deadline
+// expires (os.ErrDeadlineExceeded). An error set with SetIOReaderError is
+// returned once its byte count has been read.
+func (c *FakeConn) Read(dataBuf []byte) (int, error) {
+	c.fakeIO.mu.Lock()
+	step, ok := c.fakeIO.takeRead()
+	c.fakeIO.mu.Unlock()
+
+	var (
+		n   int
+		err error
+	)
+
+	if ok {
+		n, err = step.apply(dataBuf, c.readConn)
+	} else {
+		n, err = c.readConn(dataBuf)
+	}
+
+	c.fakeIO.pace(n)
+	c.fakeIO.observeRead(err)
+	c.fakeIO.recordCall(fmt.Sprint("Read(", len(dataBuf), ")"), n, err)
+
+	return n, err
+}
+
+// readConn reads from the pipe honoring SetIOReaderError: reads are
+// shortened to stop at the error position where the error is returned
+// (once) before reading continues.
+func (c *FakeConn) readConn(dataBuf []byte) (int, error) {
+	f := c.fakeIO
+
+	f.mu.Lock()
+	armed := f.rErr != nil && !f.rErrHit
+
+	if armed && f.rErrPos <= 0 {
+		err := f.rErr
+		f.rErrHit = true
+		f.mu.Unlock()
+
+		return 0, err
+	}
+
+	if armed && f.rErrPos < len(dataBuf) {
+		dataBuf = dataBuf[:f.rErrPos]
+	}
+
+	f.mu.Unlock()
+
+	n, err := c.readPipe(dataBuf)
+
+	if armed {
+		f.mu.Lock()
+		f.rErrPos -= n
+		f.mu.Unlock()
+	}
+
+	return n, err
+}
+
+func (c *FakeConn) readPipe(dataBuf []byte) (int, error) {
+	for {
+		if c.isClosed() {
+			return 0, net.ErrClosed
+		}
+
+		c.in.mu.Lock()
+
+		switch {
+		case len(c.in.data) > 0:
+			n := copy(dataBuf, c.in.data)
+			c.in.data = c.in.data[n:]
+			c.in.mu.Unlock()
+
+			return n, nil
+		case c.in.writeClosed || c.in.readerClosed:
+			c.in.mu.Unlock()
+
+			return 0, io.EOF
+		}
+
+		if c.in.notify == nil {
+			c.in.notify = make(chan struct{})
+		}
+
+		changed := c.in.notify
+		c.in.mu.Unlock()
+
+		var moved <-chan struct{}
+
+		deadline := c.deadline(true)
+		if !deadline.IsZero() {
+			moved = c.moved()
+		}
+
+		remaining, expired := c.expired(deadline)
+		if expired {
+			return 0, os.ErrDeadlineExceeded
+		}
+
+		if !c.waitPipe(changed, moved, remaining) {
+			return 0, os.ErrDeadlineExceeded
+		}
+	}
+}
+
+// waitPipe blocks until changed is signaled, the test clock moves or the
+// connection is closed returning false if the remaining time (when
+// positive) elapses first.
+func (c *FakeConn) waitPipe(
+	changed, moved <-chan struct{}, remaining time.Duration,
+) bool {
+	var timeout <-chan time.Time
+
+	if remaining > 0 {
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case <-changed:
+	case <-moved:
+	case <-c.closing:
+	case <-timeout:
+		return false
+	}
+
+	return true
+}
+
+// Write implements net.Conn making data available to the other end. Writes
+// never block. A write after the connection or its writing side is closed
+// fails with net.ErrClosed, one after the other end has closed fails with
+// io.ErrClosedPipe and one after the write deadline fails with
+// os.ErrDeadlineExceeded.
+func (c *FakeConn) Write(data []byte) (int, error) {
+	n, err := c.writeNext(data)
+	c.fakeIO.pace(n)
+	c.fakeIO.observeWrite()
+	c.fakeIO.recordCall(fmt.Sprint("Write(", len(data), ")"), n, err)
+
+	return n, err
+}
+
+func (c *FakeConn) writeNext(data []byte) (int, error) {
+	c.fakeIO.mu.Lock()
+	defer c.fakeIO.mu.Unlock()
+
+	if step, ok := c.fakeIO.takeWrite(); ok {
+		return step.apply(data, c.writePipe)
+	}
+
+	return c.writePipe(data)
+}
+
+// writePipe records and forwards data. The caller must hold the FakeIO
+// lock.
+func (c *FakeConn) writePipe(data []byte) (int, error) {
+	if c.isClosed() {
+		return 0, net.ErrClosed
+	}
+
+	if _, expired := c.expired(c.deadline(false)); expired {
+		return 0, os.ErrDeadlineExceeded
+	}
+
+	c.out.mu.Lock()
+	defer c.out.mu.Unlock()
+
+	switch {
+	case c.out.writeClosed:
+		return 0, net.ErrClosed
+	case c.out.readerClosed:
+		return 0, io.ErrClosedPipe
+	}
+
+	n, err := c.fakeIO.write(data)
+
+	c.out.data = append(c.out.data, data[:n]...)
+	c.out.wake()
+
+	return n, err
+}
+
+// Close implements net.Conn. Pending and future reads on this end fail with
+// net.ErrClosed while the other end reads io.EOF once buffered data is
+// consumed. Closing an already closed connection returns net.ErrClosed.
+// An error primed with SetCloseError is returned once.
+func (c *FakeConn) Close() error {
+	err := c.closeConn()
+	c.fakeIO.observeClose()
+	c.fakeIO.recordCall("Close()", nil, err)
+
+	return err
+}
+
+func (c *FakeConn) closeConn() error {
+	c.connMu.Lock()
+
+	if c.closed {
+		c.connMu.Unlock()
+
+		return net.ErrClosed
+	}
+
+	c.closed = true
+	close(c.closing)
+	c.connMu.Unlock()
+
+	c.out.mu.Lock()
+	c.out.writeClosed = true
+	c.out.wake()
+	c.out.mu.Unlock()
+
+	c.in.mu.Lock()
+	c.in.readerClosed = true
+	c.in.wake()
+	c.in.mu.Unlock()
+
+	return c.fakeIO.close()
+}
+
+// CloseRead shuts down the reading side discarding any data not yet read.
+// Subsequent reads return io.EOF and writes from the other end fail with
+// io.ErrClosedPipe.
+func (c *FakeConn) CloseRead() error {
+	err := c.closeRead()
+	c.fakeIO.recordCall("CloseRead()", nil, err)
+
+	return err
+}
+
+func (c *FakeConn) closeRead() error {
+	if c.isClosed() {
+		return net.ErrClosed
+	}
+
+	c.in.mu.Lock()
+	defer c.in.mu.Unlock()
+
+	c.in.readerClosed = true
+	c.in.data = nil
+	c.in.wake()
+
+	return nil
+}
+
+// CloseWrite shuts down the writing side. The other end reads io.EOF once
+// buffered data is consumed and subsequent writes fail with net.ErrClosed.
+func (c *FakeConn) CloseWrite() error {
+	err := c.closeWrite()
+	c.fakeIO.recordCall("CloseWrite()", nil, err)
+
+	return err
+}
+
+func (c *FakeConn) closeWrite() error {
+	if c.isClosed() {
+		return net.ErrClosed
+	}
+
+	c.out.mu.Lock()
+	defer c.out.mu.Unlock()
+
+	c.out.writeClosed = true
+	c.out.wake()
+
+	return nil
+}
+
+// LocalAddr implements net.Conn.
+func (c *FakeConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+// RemoteAddr implements net.Conn.
+func (c *FakeConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// SetDeadline implements net.Conn setting both the read and write
+// deadlines.
+func (c *FakeConn) SetDeadline(t time.Time) error {
+	_ = c.SetReadDeadline(t)
+
+	return c.SetWriteDeadline(t)
+}
+
+// SetReadDeadline implements net.Conn. A zero value disables the deadline.
+// Blocked reads re-evaluate the new deadline immediately.
+func (c *FakeConn) SetReadDeadline(t time.Time) error {
+	c.connMu.Lock()
+	c.readDeadline = t
+	c.connMu.Unlock()
+
+	c.in.mu.Lock()
+	c.in.wake()
+	c.in.mu.Unlock()
+
+	return nil
+}
+
+// SetWriteDeadline implements net.Conn. A zero value disables the deadline.
+func (c *FakeConn) SetWriteDeadline(t time.Time) error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	c.writeDeadline = t
+
+	return nil
+}
+
+// Name returns the identifier used for the connection in failure messages.
+func (c *FakeConn) Name() string {
+	return c.fakeIO.Name()
+}
+
+// SetReadError primes the next Read to return (pos, err) without reading
+// from the other end.
+func (c *FakeConn) SetReadError(pos int, err error) {
+	c.fakeIO.SetReadError(pos, err)
+}
+
+// SetIOReaderError configures Read to return err once byteCount bytes
+// written by the other end have been read. Reading then continues
+// normally.
+func (c *FakeConn) SetIOReaderError(byteCount int, err error) {
+	c.fakeIO.SetIOReaderError(byteCount, err)
+}
+
+// SetWriteError primes the next Write to return (pos, err) without
+// forwarding any data to the other end.
+func (c *FakeConn) SetWriteError(pos int, err error) {
+	c.fakeIO.SetWriteError(pos, err)
+}
+
+// SetIOWriterError configures Write to return err once n bytes have been
+// forwarded to the other end.
+func (c *FakeConn) SetIOWriterError(n int, err error) {
+	c.fakeIO.SetIOWriterError(n, err)
+}
+
+// SetReadSchedule scripts the outcome of the following Read calls. See
+// FakeIO.SetReadSchedule.
+func (c *FakeConn) SetReadSchedule(steps ...IOStep) {
+	c.fakeIO.SetReadSchedule(steps...)
+}
+
+// SetWriteSchedule scripts the outcome of the following Write calls. See
+// FakeIO.SetWriteSchedule.
+func (c *FakeConn) SetWriteSchedule(steps ...IOStep) {
+	c.fakeIO.SetWriteSchedule(steps...)
+}
+
+// SetCloseError primes the next Close to return err.
+func (c *FakeConn) SetCloseError(err error) {
+	c.fakeIO.SetCloseError(err)
+}
+
+// GetIOWriterData returns all bytes written to the connection so far and
+// clears the internal buffer.
+func (c *FakeConn) GetIOWriterData() []byte {
+	return c.fakeIO.GetIOWriterData()
+}
+
+// SetDelay adds a fixed delay to every Read and Write. See
+// FakeIO.SetDelay.
+func (c *FakeConn) SetDelay(d time.Duration) {
+	c.fakeIO.SetDelay(d)
+}
+
+// SetThroughput limits Read and Write to the given rate. See
+// FakeIO.SetThroughput.
+func (c *FakeConn) SetThroughput(bytesPerSec int) {
+	c.fakeIO.SetThroughput(bytesPerSec)
+}
+
+// SetPaceWithClock selects whether pacing advances the chk test clock
+// instead of sleeping. See FakeIO.SetPaceWithClock.
+func (c *FakeConn) SetPaceWithClock(enable bool) {
+	c.fakeIO.SetPaceWithClock(enable)
+}
+
+// Strict reports misuse of the connection as a failure on
+// chk.Release(). See FakeIO.Strict.
+func (c *FakeConn) Strict() {
+	c.fakeIO.Strict()
+}
+
+// CallLog returns the calls made on the connection. See FakeIO.CallLog.
+func (c *FakeConn) CallLog() []string {
+	return c.fakeIO.CallLog()
+}
+
+// CallCount returns the number of calls made to op. See FakeIO.CallCount.
+func (c *FakeConn) CallCount(op string) int {
+	return c.fakeIO.CallCount(op)
+}
+
+// FakeListener is an in-memory net.Listener. Connections are established
+// with Dial (or DialContext, suitable for http.Transport) and returned by
+// Accept. Dial may be called from any goroutine. The listener is closed
+// automatically by chk.Release() and injected errors or scheduled steps
+// still pending on its connections are reported as failures.
+type FakeListener struct {
+	chk       *Chk
+	addr      net.Addr
+	conns     chan *FakeConn
+	closed    chan struct{}
+	closeOnce sync.Once
+	mu        sync.Mutex
+	dialCount int
+	fakes     []*FakeIO
+}
+
+// NewFakeListener returns an in-memory net.Listener reporting addr as its
+// address.
+func (chk *Chk) NewFakeListener(addr string) *FakeListener {
+	chk.t.Helper()
+
+	listener := &FakeListener{
+		chk:    chk,
+		addr:   fakeAddr(addr),
+		conns:  make(chan *FakeConn, fakeListenerBacklog),
+		closed: make(chan struct{}),
+	}
+
+	chk.PushPreReleaseFunc(func() error {
+		chk.t.Helper()
+
+		_ = listener.Close()
+
+		listener.mu.Lock()
+		defer listener.mu.Unlock()
+
+		for _, fake := range listener.fakes {
+			chk.reportUnused(fake)
+		}
+
+		return nil
+	})
+
+	return listener
+}
+
+// Accept implements net.Listener waiting for the next dialed connection.
+// It returns net.ErrClosed once the listener is closed.
+func (l *FakeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements net.Listener. Blocked and future Accept calls return
+// net.ErrClosed. Closing an already closed listener returns net.ErrClosed.
+func (l *FakeListener) Close() error {
+	err := net.ErrClosed
+
+	l.closeOnce.Do(func() {
+		close(l.closed)
+
+		err = nil
+	})
+
+	return err
+}
+
+// Addr implements net.Listener.
+func (l *FakeListener) Addr() net.Addr {
+	return l.addr
+}
+
+// Dial connects to the listener returning the client end. The server end
+// is queued for Accept. It fails with net.ErrClosed if the listener is
+// closed.
+func (l *FakeListener) Dial() (*FakeConn, error) {
+	select {
+	case <-l.closed:
+		return nil, net.ErrClosed
+	default:
+	}
+
+	l.mu.Lock()
+	l.dialCount++
+	client := fmt.Sprint("client-", l.dialCount)
+	clientFake := newFakeIO(l.addr.String() + "/" + client)
+	serverFake := newFakeIO(l.addr.String() + "/accepted-" + client)
+	clientFake.advance = l.chk.advanceClock
+	serverFake.advance = l.chk.advanceClock
+	l.fakes = append(l.fakes, clientFake, serverFake)
+	l.mu.Unlock()
+
+	clientConn, serverConn := l.chk.newFakeConnPair(
+		clientFake, serverFake, client, l.addr.String(),
+	)
+
+	select {
+	case l.conns <- serverConn:
+	default:
+		return nil, ErrListenerBacklog
+	}
+
+	return clientConn, nil
+}
+
+// DialContext has the signature expected by net.Dialer style hooks such as
+// http.Transport.DialContext. The network and address are ignored.
+func (l *FakeListener) DialContext(
+	ctx context.Context, _, _ string,
+) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Ok.
+	}
+
+	return l.Dial()
+}
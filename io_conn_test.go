@@ -0,0 +1,439 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func tstChkIoConn(t *testing.T) {
+	t.Run("Pair", chkIoConnTestPair)
+	t.Run("Blocking", chkIoConnTestBlocking)
+	t.Run("Close", chkIoConnTestClose)
+	t.Run("CloseRead", chkIoConnTestCloseRead)
+	t.Run("DeadlineExpired", chkIoConnTestDeadlineExpired)
+	t.Run("DeadlineWait", chkIoConnTestDeadlineWait)
+	t.Run("DeadlineClockMoved", chkIoConnTestDeadlineClockMoved)
+	t.Run("Injection", chkIoConnTestInjection)
+	t.Run("ReaderError", chkIoConnTestReaderError)
+	t.Run("Listener", chkIoConnTestListener)
+	t.Run("ListenerHTTP", chkIoConnTestListenerHTTP)
+	t.Run("ListenerUnused", chkIoConnTestListenerUnused)
+}
+
+func chkIoConnTestPair(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	var client, server net.Conn
+
+	client, server = chk.NewFakeConnPair()
+
+	chk.Str(client.LocalAddr().String(), "fake-client")
+	chk.Str(client.RemoteAddr().String(), "fake-server")
+	chk.Str(server.LocalAddr().String(), "fake-server")
+	chk.Str(server.RemoteAddr().Network(), "fake")
+
+	n, err := client.Write([]byte("ping"))
+	chk.NoErr(err)
+	chk.Int(n, 4)
+
+	chk.NoErr(client.(*FakeConn).CloseWrite())
+
+	data, err := io.ReadAll(server)
+	chk.NoErr(err)
+	chk.Str(string(data), "ping")
+
+	_, err = client.Write([]byte("late"))
+	chk.Err(err, net.ErrClosed.Error())
+
+	_, err = server.Write([]byte("pong"))
+	chk.NoErr(err)
+
+	buf := make([]byte, 10)
+
+	n, err = client.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "pong")
+
+	chk.Str(string(client.(*FakeConn).GetIOWriterData()), "ping")
+}
+
+func chkIoConnTestBlocking(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	client, server := chk.NewFakeConnPair()
+
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+
+		_, _ = server.Write([]byte("later"))
+	}()
+
+	buf := make([]byte, 10)
+
+	n, err := client.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "later")
+}
+
+func chkIoConnTestClose(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	client, server := chk.NewFakeConnPair()
+
+	_, err := client.Write([]byte("unread"))
+	chk.NoErr(err)
+
+	done := make(chan error)
+
+	go func() {
+		_, readErr := client.Read(make([]byte, 10))
+		done <- readErr
+	}()
+
+	time.Sleep(time.Millisecond * 10)
+	chk.NoErr(client.Close())
+	chk.Err(<-done, net.ErrClosed.Error())
+
+	chk.Err(client.Close(), net.ErrClosed.Error())
+
+	_, err = client.Write([]byte("x"))
+	chk.Err(err, net.ErrClosed.Error())
+
+	data, err := io.ReadAll(server)
+	chk.NoErr(err)
+	chk.Str(string(data), "unread")
+
+	_, err = server.Write([]byte("x"))
+	chk.Err(err, io.ErrClosedPipe.Error())
+
+	chk.Err(client.CloseRead(), net.ErrClosed.Error())
+	chk.Err(client.CloseWrite(), net.ErrClosed.Error())
+}
+
+func chkIoConnTestCloseRead(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	client, server := chk.NewFakeConnPair()
+
+	_, err := client.Write([]byte("discarded"))
+	chk.NoErr(err)
+
+	chk.NoErr(server.CloseRead())
+
+	_, err = server.Read(make([]byte, 10))
+	chk.Err(err, io.EOF.Error())
+
+	_, err = client.Write([]byte("x"))
+	chk.Err(err, io.ErrClosedPipe.Error())
+
+	_, err = server.Write([]byte("still writing"))
+	chk.NoErr(err)
+}
+
+func chkIoConnTestDeadlineExpired(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now)()
+
+	client, _ := chk.NewFakeConnPair()
+
+	chk.NoErr(client.SetDeadline(now))
+
+	_, err := client.Read(make([]byte, 10))
+	chk.True(errors.Is(err, os.ErrDeadlineExceeded))
+
+	_, err = client.Write([]byte("x"))
+	chk.True(errors.Is(err, os.ErrDeadlineExceeded))
+
+	chk.NoErr(client.SetWriteDeadline(now.Add(time.Hour)))
+
+	_, err = client.Write([]byte("x"))
+	chk.NoErr(err)
+
+	chk.NoErr(client.SetDeadline(time.Time{}))
+
+	_, err = client.Write([]byte("x"))
+	chk.NoErr(err)
+}
+
+func chkIoConnTestDeadlineWait(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now)()
+
+	client, server := chk.NewFakeConnPair()
+
+	chk.NoErr(client.SetReadDeadline(now.Add(time.Millisecond * 10)))
+
+	start := time.Now()
+	_, err := client.Read(make([]byte, 10))
+	chk.True(errors.Is(err, os.ErrDeadlineExceeded))
+	chk.True(time.Since(start) >= time.Millisecond*10)
+
+	chk.NoErr(client.SetReadDeadline(time.Time{}))
+
+	_, err = server.Write([]byte("ok"))
+	chk.NoErr(err)
+
+	buf := make([]byte, 10)
+
+	n, err := client.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "ok")
+}
+
+func chkIoConnTestDeadlineClockMoved(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now, time.Hour*2)()
+
+	client, _ := chk.NewFakeConnPair()
+	chk.NoErr(client.SetReadDeadline(now.Add(time.Hour)))
+
+	result := make(chan error)
+
+	go func() {
+		_, err := client.Read(make([]byte, 10))
+		result <- err
+	}()
+
+	time.Sleep(time.Millisecond * 10)
+	chk.ClockNext()
+
+	chk.True(errors.Is(<-result, os.ErrDeadlineExceeded))
+
+	// Pacing another fake with the clock also wakes a blocked read.
+	chk.NoErr(client.SetReadDeadline(chk.ClockLast().Add(time.Hour * 3)))
+
+	go func() {
+		_, err := client.Read(make([]byte, 10))
+		result <- err
+	}()
+
+	pacer := chk.NewFakeWriter()
+	pacer.SetDelay(time.Hour * 4)
+	pacer.SetPaceWithClock(true)
+
+	time.Sleep(time.Millisecond * 10)
+
+	_, err := pacer.Write([]byte("tick"))
+	chk.NoErr(err)
+
+	chk.True(errors.Is(<-result, os.ErrDeadlineExceeded))
+}
+
+func chkIoConnTestInjection(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	client, server := chk.NewFakeConnPair()
+
+	client.SetWriteSchedule(IOStepShort(2))
+	server.SetReadError(0, errors.New("connection reset"))
+	server.SetCloseError(errors.New("close failed"))
+
+	n, err := client.Write([]byte("hello"))
+	chk.NoErr(err)
+	chk.Int(n, 2)
+
+	_, err = server.Read(make([]byte, 10))
+	chk.Err(err, "connection reset")
+
+	server.SetReadSchedule(IOStepShort(1))
+
+	buf := make([]byte, 10)
+
+	n, err = server.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "h")
+
+	n, err = server.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "e")
+
+	chk.Err(server.Close(), "close failed")
+}
+
+func chkIoConnTestReaderError(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	client, server := chk.NewFakeConnPair()
+
+	server.SetIOReaderError(2, errors.New("boom"))
+
+	_, err := client.Write([]byte("hello"))
+	chk.NoErr(err)
+
+	buf := make([]byte, 10)
+
+	n, err := server.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "he")
+
+	n, err = server.Read(buf)
+	chk.Err(err, "boom")
+	chk.Int(n, 0)
+
+	n, err = server.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "llo")
+}
+
+func chkIoConnTestListener(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	var listener net.Listener = chk.NewFakeListener("fake-server:80")
+
+	chk.Str(listener.Addr().String(), "fake-server:80")
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			data, _ := io.ReadAll(conn)
+			_, _ = fmt.Fprintf(conn, "echo %s from %s", data, conn.RemoteAddr())
+			_ = conn.Close()
+		}
+	}()
+
+	fakeListener, _ := listener.(*FakeListener)
+
+	for i := range 2 {
+		conn, err := fakeListener.Dial()
+		chk.NoErr(err)
+		chk.Str(conn.RemoteAddr().String(), "fake-server:80")
+
+		_, err = fmt.Fprint(conn, "msg", i)
+		chk.NoErr(err)
+		chk.NoErr(conn.CloseWrite())
+
+		data, err := io.ReadAll(conn)
+		chk.NoErr(err)
+		chk.Str(string(data), fmt.Sprint("echo msg", i, " from client-", i+1))
+	}
+
+	chk.NoErr(listener.Close())
+	<-done
+
+	chk.Err(listener.Close(), net.ErrClosed.Error())
+
+	_, err := fakeListener.Dial()
+	chk.Err(err, net.ErrClosed.Error())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = fakeListener.DialContext(ctx, "tcp", "ignored")
+	chk.Err(err, context.Canceled.Error())
+}
+
+func chkIoConnTestListenerHTTP(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	listener := chk.NewFakeListener("fake-server:80")
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "hello over fake")
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	transport := &http.Transport{DialContext: listener.DialContext}
+	defer transport.CloseIdleConnections()
+
+	client := &http.Client{Transport: transport}
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, "http://fake-server/", nil,
+	)
+	chk.NoErr(err)
+
+	resp, err := client.Do(req)
+	chk.NoErr(err)
+
+	body, err := io.ReadAll(resp.Body)
+	chk.NoErr(err)
+	chk.NoErr(resp.Body.Close())
+	chk.Str(string(body), "hello over fake")
+
+	chk.NoErr(server.Close())
+}
+
+func chkIoConnTestListenerUnused(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	listener := chk.NewFakeListener("fake-server:80")
+
+	conn, err := listener.Dial()
+	chk.NoErr(err)
+
+	conn.SetCloseError(errors.New("never closed"))
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("NewFakeListener"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("NewFakeListener.func1"),
+		chkOutHelper("reportUnused"),
+		chkOutError(
+			"fake-server:80/client-1: injected fault never triggered: "+
+				"SetCloseError(never closed)",
+		),
+		chkOutHelper("reportUnused"),
+	)
+}
@@ -0,0 +1,218 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FakeIO is an in-memory io.Reader, io.Writer, io.Seeker and io.Closer with
+// the same data and error injection methods as those implemented directly
+// on *Chk. Independent instances are created with chk.NewFakeReader,
+// chk.NewFakeWriter and chk.NewFakeReadWriteCloser so that a single test
+// may use several fakes at once (for example as the source and the sink of
+// an io.Copy). All methods are safe for concurrent use.
+type FakeIO struct {
+	mu   sync.Mutex
+	name string
+
+	// io.Reader and io.Writer field
+	rData   []byte
+	rPos    int
+	rLeft   int
+	rErrPos int
+	rErr    error
+	rErrHit bool
+
+	wData   []byte
+	wErrPos int
+	wErr    error
+	wErrHit bool
+
+	ioSeekErrPos int64
+	ioSeekErr    error
+
+	ioReadErrPos int
+	ioReadErr    error
+
+	ioWriteErrPos int
+	ioWriteErr    error
+
+	ioCloseErr error
+
+	ioSeekErrSet  bool
+	ioReadErrSet  bool
+	ioWriteErrSet bool
+	ioCloseErrSet bool
+
+	rSchedule []IOStep
+	wSchedule []IOStep
+
+	delay         time.Duration
+	bytesPerSec   int
+	paceWithClock bool
+	advance       func(time.Duration)
+
+	logMu       sync.Mutex
+	calls       []string
+	strict      bool
+	eofSeen     bool
+	closeCount  int
+	misuseOrder []string
+	misuseCount map[string]int
+}
+
+func newFakeIO(name string) *FakeIO {
+	return &FakeIO{
+		name:    name,
+		rData:   make([]byte, 0),
+		rErrPos: -1,
+		wData:   make([]byte, 0),
+		wErrPos: -1,
+	}
+}
+
+// Name returns the identifier used for the fake in failure messages.
+func (f *FakeIO) Name() string {
+	return f.name
+}
+
+// unusedFaults lists the injected errors that were primed but never
+// returned to the code under test.
+func (f *FakeIO) unusedFaults() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var faults []string
+
+	if f.rErr != nil && !f.rErrHit {
+		faults = append(faults, fmt.Sprint(
+			"SetIOReaderError(", f.rErrPos, ", ", f.rErr, ")",
+		))
+	}
+
+	if f.ioReadErrSet {
+		faults = append(faults, fmt.Sprint(
+			"SetReadError(", f.ioReadErrPos, ", ", f.ioReadErr, ")",
+		))
+	}
+
+	if f.wErr != nil && !f.wErrHit {
+		faults = append(faults, fmt.Sprint(
+			"SetIOWriterError(", f.wErrPos, ", ", f.wErr, ")",
+		))
+	}
+
+	if f.ioWriteErrSet {
+		faults = append(faults, fmt.Sprint(
+			"SetWriteError(", f.ioWriteErrPos, ", ", f.ioWriteErr, ")",
+		))
+	}
+
+	if f.ioSeekErrSet {
+		faults = append(faults, fmt.Sprint(
+			"SetSeekError(", f.ioSeekErrPos, ", ", f.ioSeekErr, ")",
+		))
+	}
+
+	if f.ioCloseErrSet {
+		faults = append(faults, fmt.Sprint("SetCloseError(", f.ioCloseErr, ")"))
+	}
+
+	return faults
+}
+
+func (chk *Chk) newFake(kind string) *FakeIO {
+	chk.t.Helper()
+
+	chk.nextFakeID++
+	fake := newFakeIO(fmt.Sprint(kind, "#", chk.nextFakeID))
+	fake.advance = chk.advanceClock
+
+	chk.PushPreReleaseFunc(func() error {
+		chk.t.Helper()
+
+		chk.reportUnused(fake)
+
+		return nil
+	})
+
+	return fake
+}
+
+// reportUnused fails the test for every injected fault or scheduled step
+// never consumed by the code under test and, for strict fakes, every
+// protocol violation unless other errors have already been reported.
+func (chk *Chk) reportUnused(fake *FakeIO) {
+	chk.t.Helper()
+
+	if chk.faultCount != 0 {
+		return
+	}
+
+	for _, fault := range fake.unusedFaults() {
+		chk.Error(fake.name, ": injected fault never triggered: ", fault)
+	}
+
+	for _, msg := range fake.unusedSchedule() {
+		chk.Error(fake.name, ": ", msg)
+	}
+
+	for _, violation := range fake.violations() {
+		chk.Error(fake.name, ": protocol violation: ", violation)
+	}
+}
+
+// NewFakeReader returns an independent *FakeIO serving the concatenated
+// data strings through Read, as with chk.SetIOReaderData. Any injected
+// error or scheduled step still pending when chk.Release() is called is
+// reported as a failure.
+func (chk *Chk) NewFakeReader(data ...string) *FakeIO {
+	chk.t.Helper()
+
+	fake := chk.newFake("FakeReader")
+	fake.SetIOReaderData(data...)
+
+	return fake
+}
+
+// NewFakeWriter returns an independent *FakeIO recording everything
+// written to it. Retrieve the data with GetIOWriterData. Any injected error
+// or scheduled step still pending when chk.Release() is called is reported
+// as a failure.
+func (chk *Chk) NewFakeWriter() *FakeIO {
+	chk.t.Helper()
+
+	return chk.newFake("FakeWriter")
+}
+
+// NewFakeReadWriteCloser returns an independent *FakeIO serving the
+// concatenated data strings through Read while recording writes and
+// accepting Close. Any injected error or scheduled step still pending when
+// chk.Release() is called is reported as a failure.
+func (chk *Chk) NewFakeReadWriteCloser(data ...string) *FakeIO {
+	chk.t.Helper()
+
+	fake := chk.newFake("FakeReadWriteCloser")
+	fake.SetIOReaderData(data...)
+
+	return fake
+}
@@ -0,0 +1,167 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func tstChkIoFake(t *testing.T) {
+	t.Run("Copy", chkIoFakeTestCopy)
+	t.Run("ReadWriteCloser", chkIoFakeTestReadWriteCloser)
+	t.Run("Independent", chkIoFakeTestIndependent)
+	t.Run("TriggeredFaults", chkIoFakeTestTriggeredFaults)
+	t.Run("UnusedFaults", chkIoFakeTestUnusedFaults)
+}
+
+func chkIoFakeTestCopy(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	src := chk.NewFakeReader("line 1\n", "line 2\n")
+	dst := chk.NewFakeWriter()
+
+	n, err := io.Copy(dst, src)
+	chk.NoErr(err)
+	chk.Int64(n, 14)
+	chk.Str(string(dst.GetIOWriterData()), "line 1\nline 2\n")
+
+	chk.Str(src.Name(), "FakeReader#1")
+	chk.Str(dst.Name(), "FakeWriter#2")
+}
+
+func chkIoFakeTestReadWriteCloser(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	var rwc io.ReadWriteCloser = chk.NewFakeReadWriteCloser("request")
+
+	data, err := io.ReadAll(rwc)
+	chk.NoErr(err)
+	chk.Str(string(data), "request")
+
+	n, err := rwc.Write([]byte("response"))
+	chk.NoErr(err)
+	chk.Int(n, 8)
+
+	chk.NoErr(rwc.Close())
+
+	fake, ok := rwc.(*FakeIO)
+	chk.True(ok)
+	chk.Str(string(fake.GetIOWriterData()), "response")
+}
+
+func chkIoFakeTestIndependent(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.SetIOReaderData("chk data")
+
+	fake := chk.NewFakeReader("fake data")
+
+	buf := make([]byte, 100)
+
+	n, err := fake.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "fake data")
+
+	n, err = chk.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "chk data")
+
+	_, err = chk.Write([]byte("to chk"))
+	chk.NoErr(err)
+
+	chk.Str(string(chk.GetIOWriterData()), "to chk")
+}
+
+func chkIoFakeTestTriggeredFaults(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeReadWriteCloser("abc")
+
+	fake.SetIOReaderError(2, errors.New("read failed"))
+	fake.SetIOWriterError(1, errors.New("write failed"))
+	fake.SetSeekError(3, errors.New("seek failed"))
+	fake.SetCloseError(errors.New("close failed"))
+
+	buf := make([]byte, 10)
+
+	n, err := fake.Read(buf)
+	chk.NoErr(err)
+	chk.Int(n, 2)
+
+	_, err = fake.Read(buf)
+	chk.Err(err, "read failed")
+
+	n, err = fake.Write([]byte("xyz"))
+	chk.Err(err, "write failed")
+	chk.Int(n, 1)
+
+	pos, err := fake.Seek(0, io.SeekStart)
+	chk.Err(err, "seek failed")
+	chk.Int64(pos, 3)
+
+	chk.Err(fake.Close(), "close failed")
+}
+
+func chkIoFakeTestUnusedFaults(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	orig := chk.FailFast(false)
+	defer chk.FailFast(orig)
+
+	reader := chk.NewFakeReader("abc")
+	reader.SetReadError(1, errors.New("never read"))
+
+	writer := chk.NewFakeWriter()
+	writer.SetWriteError(0, errors.New("never written"))
+	writer.SetCloseError(errors.New("never closed"))
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("NewFakeReader"),
+		chkOutHelper("newFake"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("NewFakeWriter"),
+		chkOutHelper("newFake"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutHelper("newFake.func1"),
+		chkOutHelper("reportUnused"),
+		chkOutErrorNoFail(
+			"FakeWriter#2: injected fault never triggered: "+
+				"SetWriteError(0, never written)",
+		),
+		chkOutErrorNoFail(
+			"FakeWriter#2: injected fault never triggered: "+
+				"SetCloseError(never closed)",
+		),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("newFake.func1"),
+		chkOutHelper("reportUnused"),
+	)
+}
@@ -0,0 +1,207 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// InteractStep is a single exchange in a scripted conversation run by
+// Chk.Interact. Answer is written to os.Stdin (followed by a newline) only
+// after Prompt has appeared on the captured stdout.
+type InteractStep struct {
+	Prompt string
+	Answer string
+}
+
+// interactTranscript records the conversation for failure reporting with
+// program output prefixed by "> " and answers prefixed by "< ".
+type interactTranscript struct {
+	lines []string
+}
+
+func (tr *interactTranscript) output(data string) {
+	if data == "" {
+		return
+	}
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(data, "\n"), "\n") {
+		tr.lines = append(tr.lines, "> "+line)
+	}
+}
+
+func (tr *interactTranscript) answer(data string) {
+	tr.lines = append(tr.lines, "< "+data)
+}
+
+func (tr *interactTranscript) String() string {
+	return "interaction transcript [\n" + strings.Join(tr.lines, "\n") + "\n]"
+}
+
+// waitForPrompt watches capBuf for prompt appearing at or after pos. It
+// returns the output seen up to and including the prompt, the position just
+// past it and whether it was found before the deadline.
+func waitForPrompt(
+	capBuf *captureBuf, prompt string, pos int, deadline time.Time,
+) (string, int, bool) {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	for {
+		data, changed := capBuf.watch()
+		if pos > len(data) {
+			pos = len(data)
+		}
+
+		idx := strings.Index(data[pos:], prompt)
+		if idx >= 0 {
+			end := pos + idx + len(prompt)
+
+			return data[pos:end], end, true
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return data[pos:], len(data), false
+		}
+	}
+}
+
+// Interact runs a scripted conversation with code reading os.Stdin. The
+// function run is started in its own goroutine with os.Stdin replaced by a
+// pipe. For each step the captured stdout is watched (from the end of the
+// previous prompt) until the literal Prompt appears, then the Answer is
+// written. Once every step is answered os.Stdin is closed and Interact
+// waits for run to return.
+//
+// Each step, and the final wait for run, must complete within timeout. On
+// timeout os.Stdin is closed and the failure is reported with a transcript
+// interleaving the program output and the answers supplied. A panic in run
+// is recovered and reported the same way. Stdout must be
+// captured. Interacting does not count as a check so the stdout output must
+// still be verified before chk.Release(). Returns true if the conversation
+// completed.
+func (chk *Chk) Interact(
+	timeout time.Duration, run func(), steps ...InteractStep,
+) bool {
+	chk.t.Helper()
+
+	name, capBuf, _ := chk.stdoutSource()
+	if capBuf == nil {
+		chk.Error(
+			"invalid os.Stdout interaction without information being captured",
+		)
+
+		return false
+	}
+
+	rPipe, wPipe, err := os.Pipe()
+	if !chk.NoErr(err) {
+		return false
+	}
+
+	origStdin := os.Stdin
+	os.Stdin = rPipe
+
+	defer func() {
+		os.Stdin = origStdin
+		_ = rPipe.Close()
+	}()
+
+	transcript := new(interactTranscript)
+	pos := len(capBuf.String())
+	done := make(chan any, 1)
+
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+
+		run()
+	}()
+
+	failMsg := ""
+
+	for i, step := range steps {
+		seen, next, found := waitForPrompt(
+			capBuf, step.Prompt, pos, time.Now().Add(timeout),
+		)
+		transcript.output(seen)
+		pos = next
+
+		if !found {
+			failMsg = fmt.Sprintf(
+				"timeout after %v waiting for prompt %d: %q",
+				timeout, i+1, step.Prompt,
+			)
+
+			break
+		}
+
+		transcript.answer(step.Answer)
+
+		_, err = fmt.Fprintln(wPipe, step.Answer)
+		if err != nil {
+			failMsg = fmt.Sprintf("could not answer prompt %d: %v", i+1, err)
+
+			break
+		}
+	}
+
+	_ = wPipe.Close()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case panicked := <-done:
+		if panicked != nil {
+			if failMsg != "" {
+				failMsg += "; "
+			}
+
+			failMsg += fmt.Sprint("interaction panicked: ", panicked)
+		}
+	case <-timer.C:
+		if failMsg == "" {
+			failMsg = fmt.Sprintf(
+				"timeout after %v waiting for interaction to finish", timeout,
+			)
+		}
+	}
+
+	if failMsg == "" {
+		return true
+	}
+
+	time.Sleep(pauseTimeForLogToCatchup)
+
+	data := capBuf.String()
+	if pos < len(data) {
+		transcript.output(data[pos:])
+	}
+
+	chk.Error(errMsgHeader(name, failMsg) + transcript.String())
+
+	return false
+}
@@ -0,0 +1,213 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func tstChkIoInteract(t *testing.T) {
+	t.Run("Conversation", chkIoInteractTestConversation)
+	t.Run("NotCaptured", chkIoInteractTestNotCaptured)
+	t.Run("PromptTimeout", chkIoInteractTestPromptTimeout)
+	t.Run("FinishTimeout", chkIoInteractTestFinishTimeout)
+	t.Run("Panic", chkIoInteractTestPanic)
+}
+
+// interactProgram is a small prompt/response program reading os.Stdin.
+//
+//nolint:forbidigo // Ok testing print capture.
+func interactProgram() {
+	scanner := bufio.NewScanner(os.Stdin)
+
+	fmt.Print("name? ")
+
+	if !scanner.Scan() {
+		return
+	}
+
+	fmt.Println("hello " + scanner.Text())
+	fmt.Print("age? ")
+
+	if !scanner.Scan() {
+		return
+	}
+
+	fmt.Println("you are " + scanner.Text())
+}
+
+func chkIoInteractTestConversation(t *testing.T) {
+	chk := CaptureStdout(t)
+	defer chk.Release()
+
+	origStdin := os.Stdin
+
+	chk.True(
+		chk.Interact(time.Second, interactProgram,
+			InteractStep{Prompt: "name? ", Answer: "bob"},
+			InteractStep{Prompt: "age? ", Answer: "42"},
+		),
+	)
+
+	chk.True(os.Stdin == origStdin)
+
+	chk.Stdout(
+		"name? hello bob",
+		"age? you are 42",
+	)
+}
+
+func chkIoInteractTestNotCaptured(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	chk.Interact(time.Second, func() {})
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("Interact"),
+		chkOutError(
+			"invalid os.Stdout interaction without information being captured",
+		),
+		chkOutRelease(),
+	)
+}
+
+func chkIoInteractTestPromptTimeout(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.Interact(time.Millisecond*20, interactProgram,
+		InteractStep{Prompt: "name? ", Answer: "bob"},
+		InteractStep{Prompt: "colour? ", Answer: "red"},
+	)
+
+	chk.Stdout("name? hello bob", "age? ")
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Interact"),
+		chkOutError(
+			chkOutCommonMsg(
+				`timeout after 20ms waiting for prompt 2: "colour? "`,
+				"stdout",
+			),
+			"interaction transcript [",
+			"> name? ",
+			"< bob",
+			"> hello bob",
+			"> age? ",
+			"]",
+		),
+		chkOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkIoInteractTestFinishTimeout(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	release := make(chan struct{})
+
+	chk.Interact(time.Millisecond*20, func() {
+		fmt.Println("busy")
+		<-release
+	})
+
+	close(release)
+
+	chk.Stdout("busy")
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Interact"),
+		chkOutError(
+			chkOutCommonMsg(
+				"timeout after 20ms waiting for interaction to finish",
+				"stdout",
+			),
+			"interaction transcript [",
+			"> busy",
+			"]",
+		),
+		chkOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
+
+//nolint:forbidigo // Ok testing print capture.
+func chkIoInteractTestPanic(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureStdout(iT)
+	iT.chk = chk
+
+	chk.Interact(time.Second, func() {
+		scanner := bufio.NewScanner(os.Stdin)
+
+		fmt.Print("name? ")
+		scanner.Scan()
+		fmt.Println("crashing")
+
+		panic("boom")
+	},
+		InteractStep{Prompt: "name? ", Answer: "bob"},
+	)
+
+	chk.Stdout("name? crashing")
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Stdout"),
+		chkOutHelper("setupStdoutLogger"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("Interact"),
+		chkOutError(
+			chkOutCommonMsg("interaction panicked: boom", "stdout"),
+			"interaction transcript [",
+			"> name? ",
+			"< bob",
+			"> crashing",
+			"]",
+		),
+		chkOutHelper("Stdout"),
+		chkOutHelper("compareLog"),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+	)
+}
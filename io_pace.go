@@ -0,0 +1,111 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"time"
+)
+
+// SetDelay makes every Read and Write call on the fake take an additional
+// d. See SetPaceWithClock for how the time is spent.
+func (f *FakeIO) SetDelay(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delay = max(d, 0)
+}
+
+// SetThroughput limits the fake to bytesPerSec by making each Read and
+// Write call take the time needed to transfer the bytes it returns. Zero
+// (the default) means unlimited.
+func (f *FakeIO) SetThroughput(bytesPerSec int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.bytesPerSec = max(bytesPerSec, 0)
+}
+
+// SetPaceWithClock selects how the time added by SetDelay and SetThroughput
+// is spent. By default the calling goroutine sleeps. When enabled the chk
+// test clock is advanced instead so tests remain fast and deterministic,
+// with deadlines on fake connections observing the advanced clock.
+func (f *FakeIO) SetPaceWithClock(enable bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.paceWithClock = enable
+}
+
+// pace spends the simulated time for a call transferring n bytes. It must
+// be called without holding the lock.
+func (f *FakeIO) pace(n int) {
+	f.mu.Lock()
+	d := f.delay
+
+	if f.bytesPerSec > 0 && n > 0 {
+		d += time.Duration(int64(n) * int64(time.Second) / int64(f.bytesPerSec))
+	}
+
+	useClock := f.paceWithClock && f.advance != nil
+	f.mu.Unlock()
+
+	if d <= 0 {
+		return
+	}
+
+	if useClock {
+		f.advance(d)
+
+		return
+	}
+
+	time.Sleep(d)
+}
+
+// advance moves the test clock forward by d without generating a tick.
+func (clk *tstClk) advance(d time.Duration) {
+	clk.mu.Lock()
+	defer clk.mu.Unlock()
+
+	clk.lastTS = clk.lastTS.Add(d)
+	clk.nextTS = clk.nextTS.Add(d)
+	clk.signal()
+}
+
+// advanceClock moves chk's current test clock forward by d.
+func (chk *Chk) advanceClock(d time.Duration) {
+	chk.clk.advance(d)
+}
+
+// SetIODelay makes every Read and Write call on chk take an additional d.
+func (chk *Chk) SetIODelay(d time.Duration) {
+	chk.fakeIO.SetDelay(d)
+}
+
+// SetIOThroughput limits chk's Read and Write calls to bytesPerSec. Zero
+// means unlimited.
+func (chk *Chk) SetIOThroughput(bytesPerSec int) {
+	chk.fakeIO.SetThroughput(bytesPerSec)
+}
+
+// SetIOPaceWithClock advances the test clock rather than sleeping for the
+// time added by SetIODelay and SetIOThroughput.
+func (chk *Chk) SetIOPaceWithClock(enable bool) {
+	chk.fakeIO.SetPaceWithClock(enable)
+}
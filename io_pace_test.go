@@ -0,0 +1,167 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func tstChkIoPace(t *testing.T) {
+	t.Run("DelaySleeps", chkIoPaceTestDelaySleeps)
+	t.Run("ThroughputClock", chkIoPaceTestThroughputClock)
+	t.Run("ChkDelayClock", chkIoPaceTestChkDelayClock)
+	t.Run("ConnDeadline", chkIoPaceTestConnDeadline)
+	t.Run("ConcurrentClock", chkIoPaceTestConcurrentClock)
+}
+
+func chkIoPaceTestDelaySleeps(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now)()
+
+	fake := chk.NewFakeReader("abc")
+	fake.SetDelay(time.Millisecond * 10)
+
+	start := time.Now()
+
+	n, err := fake.Read(make([]byte, 10))
+	chk.NoErr(err)
+	chk.Int(n, 3)
+
+	chk.True(time.Since(start) >= time.Millisecond*10)
+	chk.Dur(chk.ClockNext().Sub(now), 0)
+}
+
+func chkIoPaceTestThroughputClock(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now)()
+
+	src := chk.NewFakeReader(strings.Repeat("x", 500))
+	src.SetThroughput(1000)
+	src.SetPaceWithClock(true)
+
+	dst := chk.NewFakeWriter()
+	dst.SetDelay(time.Millisecond * 5)
+	dst.SetPaceWithClock(true)
+
+	buf := make([]byte, 100)
+
+	n, err := io.CopyBuffer(struct{ io.Writer }{dst}, src, buf)
+	chk.NoErr(err)
+	chk.Int64(n, 500)
+
+	// 500 bytes at 1000 bytes/s plus 5 writes of 5ms each.
+	chk.Dur(chk.ClockNext().Sub(now), time.Millisecond*525)
+}
+
+func chkIoPaceTestChkDelayClock(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now)()
+
+	chk.SetIODelay(time.Second)
+	chk.SetIOThroughput(0)
+	chk.SetIOPaceWithClock(true)
+
+	_, err := chk.Write([]byte("a"))
+	chk.NoErr(err)
+
+	_, err = chk.Write([]byte("b"))
+	chk.NoErr(err)
+
+	chk.Dur(chk.ClockNext().Sub(now), time.Second*2)
+	chk.Str(string(chk.GetIOWriterData()), "ab")
+}
+
+func chkIoPaceTestConnDeadline(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now)()
+
+	client, server := chk.NewFakeConnPair()
+	client.SetThroughput(10)
+	client.SetPaceWithClock(true)
+
+	chk.NoErr(server.SetReadDeadline(now.Add(time.Second)))
+
+	_, err := client.Write([]byte(strings.Repeat("y", 20)))
+	chk.NoErr(err)
+
+	data := make([]byte, 100)
+
+	n, err := server.Read(data)
+	chk.NoErr(err)
+	chk.Int(n, 20)
+
+	_, err = server.Read(data)
+	chk.True(errors.Is(err, os.ErrDeadlineExceeded))
+}
+
+func chkIoPaceTestConcurrentClock(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer chk.ClockSet(now)()
+
+	client, server := chk.NewFakeConnPair()
+	client.SetDelay(time.Millisecond)
+	client.SetPaceWithClock(true)
+	server.SetDelay(time.Millisecond)
+	server.SetPaceWithClock(true)
+
+	chk.NoErr(server.SetReadDeadline(now.Add(time.Hour)))
+
+	const writes = 50
+
+	done := make(chan error)
+
+	go func() {
+		for range writes {
+			if _, err := client.Write([]byte("z")); err != nil {
+				done <- err
+
+				return
+			}
+		}
+
+		done <- client.CloseWrite()
+	}()
+
+	data, err := io.ReadAll(server)
+	chk.NoErr(err)
+	chk.Int(len(data), writes)
+	chk.NoErr(<-done)
+
+	chk.True(chk.ClockNext().Sub(now) >= time.Millisecond*writes)
+}
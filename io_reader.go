@@ -21,66 +21,114 @@ package sztest
 import (
 	"fmt"
 	"io"
-	"os"
 	"strings"
 )
 
-// SetIOReaderData initializes chk’s io.Reader with the supplied strings.
+// SetIOReaderData initializes the fake io.Reader with the supplied strings.
 // The strings are concatenated and served sequentially through Read. Once
 // exhausted, Read returns io.EOF.
-func (chk *Chk) SetIOReaderData(d ...string) {
+func (f *FakeIO) SetIOReaderData(d ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	lines := ""
 	for _, e := range d {
 		lines += e
 	}
 
-	chk.rData = []byte(lines)
-	chk.rLeft = len(chk.rData)
-	chk.rPos = 0
-	chk.rErrPos = -1
-	chk.rErr = nil
+	f.rData = []byte(lines)
+	f.rLeft = len(f.rData)
+	f.rPos = 0
+	f.rErrPos = -1
+	f.rErr = nil
+	f.rErrHit = false
 }
 
-// SetIOReaderError configures chk’s io.Reader to return err once the given
-// byteCount has been read. After returning err, chk clears it and continues
+// SetIOReaderError configures the fake io.Reader to return err once the
+// given byteCount has been read. After returning err, the fake continues
 // serving any remaining data until EOF.
-func (chk *Chk) SetIOReaderError(byteCount int, err error) {
-	chk.rErrPos = byteCount
-	chk.rErr = err
+func (f *FakeIO) SetIOReaderError(byteCount int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rErrPos = byteCount
+	f.rErr = err
+	f.rErrHit = false
 }
 
-// SetReadError primes chk’s io.Reader to return (pos, err) on the next
-// call to Read. After returning, chk clears the error and resumes normal
-// reading for subsequent calls.
-func (chk *Chk) SetReadError(pos int, err error) {
-	chk.ioReadErrPos = pos
-	chk.ioReadErr = err
-	chk.ioReadErrSet = true
+// SetReadError primes the fake io.Reader to return (pos, err) on the next
+// call to Read. After returning, the error is cleared and normal reading
+// resumes for subsequent calls.
+func (f *FakeIO) SetReadError(pos int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ioReadErrPos = pos
+	f.ioReadErr = err
+	f.ioReadErrSet = true
 }
 
-// Read implements io.Reader for chk. It serves data provided by
-// SetIOReaderData, returns injected errors as configured by
-// SetIOReaderError or SetReadError, and yields io.EOF once all data is
+// Read implements io.Reader. It serves data provided by SetIOReaderData,
+// returns injected errors as configured by SetIOReaderError,
+// SetReadError or SetReadSchedule, and yields io.EOF once all data is
 // consumed.
+func (f *FakeIO) Read(dataBuf []byte) (int, error) {
+	n, err := f.readNext(dataBuf)
+	f.pace(n)
+	f.observeRead(err)
+	f.recordCall(fmt.Sprint("Read(", len(dataBuf), ")"), n, err)
+
+	return n, err
+}
+
+func (f *FakeIO) readNext(dataBuf []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if step, ok := f.takeRead(); ok {
+		return step.apply(dataBuf, f.read)
+	}
+
+	return f.read(dataBuf)
+}
+
+// takeRead returns the outcome injected for the next Read call, if any,
+// consuming it: first an error primed by SetReadError and then the next
+// step scheduled by SetReadSchedule. The caller must hold the lock.
+func (f *FakeIO) takeRead() (IOStep, bool) {
+	if f.ioReadErrSet {
+		step := IOStep{kind: ioStepFixed, n: f.ioReadErrPos, err: f.ioReadErr}
+		f.ioReadErrPos = 0
+		f.ioReadErr = nil
+		f.ioReadErrSet = false
+
+		return step, true
+	}
+
+	if len(f.rSchedule) > 0 {
+		step := f.rSchedule[0]
+		f.rSchedule = f.rSchedule[1:]
+
+		return step, true
+	}
+
+	return IOStep{}, false
+}
+
+// read serves the reader data honoring SetIOReaderError. The caller must
+// hold the lock.
 //
 //nolint:cyclop // Ok.
-func (chk *Chk) Read(dataBuf []byte) (int, error) {
-	if chk.ioReadErrSet {
-		readPos := chk.ioReadErrPos
-		readErr := chk.ioReadErr
-		chk.ioReadErrPos = 0
-		chk.ioReadErr = nil
-		chk.ioReadErrSet = false
-
-		return readPos, readErr
-	}
+func (f *FakeIO) read(dataBuf []byte) (int, error) {
+	if f.rErr != nil && (f.rErrPos <= 0 || f.rLeft <= 0) {
+		f.rErrHit = true
 
-	if chk.rErr != nil && (chk.rErrPos <= 0 || chk.rLeft <= 0) {
-		return 0, chk.rErr
+		return 0, f.rErr
 	}
 
-	if chk.rLeft <= 0 {
-		chk.rErr = ErrReadPastEndOfData
+	if f.rLeft <= 0 {
+		f.rErr = ErrReadPastEndOfData
+		f.rErrHit = true
 
 		return 0, io.EOF
 	}
@@ -88,14 +136,14 @@ func (chk *Chk) Read(dataBuf []byte) (int, error) {
 	i := 0
 	mi := len(dataBuf)
 
-	for i < mi && chk.rLeft > 0 {
-		dataBuf[i] = chk.rData[chk.rPos]
-		chk.rPos++
-		chk.rLeft--
-		chk.rErrPos--
+	for i < mi && f.rLeft > 0 {
+		dataBuf[i] = f.rData[f.rPos]
+		f.rPos++
+		f.rLeft--
+		f.rErrPos--
 		i++
 
-		if chk.rErr != nil && (chk.rErrPos <= 0 || chk.rLeft <= 0) {
+		if f.rErr != nil && (f.rErrPos <= 0 || f.rLeft <= 0) {
 			break
 		}
 	}
@@ -103,39 +151,47 @@ func (chk *Chk) Read(dataBuf []byte) (int, error) {
 	return i, nil
 }
 
+// SetIOReaderData initializes chk’s io.Reader with the supplied strings.
+// The strings are concatenated and served sequentially through Read. Once
+// exhausted, Read returns io.EOF.
+func (chk *Chk) SetIOReaderData(d ...string) {
+	chk.fakeIO.SetIOReaderData(d...)
+}
+
+// SetIOReaderError configures chk’s io.Reader to return err once the given
+// byteCount has been read. After returning err, chk clears it and continues
+// serving any remaining data until EOF.
+func (chk *Chk) SetIOReaderError(byteCount int, err error) {
+	chk.fakeIO.SetIOReaderError(byteCount, err)
+}
+
+// SetReadError primes chk’s io.Reader to return (pos, err) on the next
+// call to Read. After returning, chk clears the error and resumes normal
+// reading for subsequent calls.
+func (chk *Chk) SetReadError(pos int, err error) {
+	chk.fakeIO.SetReadError(pos, err)
+}
+
+// Read implements io.Reader for chk. It serves data provided by
+// SetIOReaderData, returns injected errors as configured by
+// SetIOReaderError or SetReadError, and yields io.EOF once all data is
+// consumed.
+func (chk *Chk) Read(dataBuf []byte) (int, error) {
+	return chk.fakeIO.Read(dataBuf)
+}
+
 // SetStdinData replaces os.Stdin with a stream that sequentially provides
 // the supplied lines. Once exhausted, reads return io.EOF. This is not part
 // of io.Reader itself but enables testing of code that directly consumes
-// os.Stdin.
+// os.Stdin. Use SetStdinStream to supply data over time instead.
 func (chk *Chk) SetStdinData(lines ...string) {
 	chk.t.Helper()
 
-	wPipeClosed := false
-
-	origStdin := os.Stdin
-	rPipe, wPipe, err := os.Pipe()
-
-	if chk.NoErr(err) {
-		chk.PushPostReleaseFunc(func() error {
-			os.Stdin = origStdin
-
-			err = wPipe.Close()
-			if err == nil || wPipeClosed {
-				err = rPipe.Close()
-			}
-
-			return err //nolint:wrapcheck // Ok.
-		})
-
+	stream := chk.SetStdinStream()
+	if stream != nil {
+		_, err := stream.WriteString(strings.Join(lines, ""))
 		chk.NoErr(err)
 
-		os.Stdin = rPipe
-
-		_, err = fmt.Fprint(wPipe, strings.Join(lines, ""))
-		chk.NoErr(err)
-
-		chk.NoErr(wPipe.Close())
-
-		wPipeClosed = true
+		chk.NoErr(stream.Close())
 	}
 }
@@ -0,0 +1,215 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ioStepKind int
+
+const (
+	ioStepOK ioStepKind = iota
+	ioStepShort
+	ioStepErr
+	ioStepResult
+	ioStepFixed // Primed by SetReadError or SetWriteError.
+)
+
+// IOStep describes the outcome of a single Read or Write call on a fake.
+// Create steps with IOStepOK, IOStepShort, IOStepErr or IOStepResult and
+// install them with SetReadSchedule or SetWriteSchedule.
+type IOStep struct {
+	kind ioStepKind
+	n    int
+	err  error
+}
+
+// IOStepOK performs the call normally.
+func IOStepOK() IOStep {
+	return IOStep{kind: ioStepOK}
+}
+
+// IOStepShort performs the call transferring at most n bytes.
+func IOStepShort(n int) IOStep {
+	return IOStep{kind: ioStepShort, n: max(n, 0)}
+}
+
+// IOStepErr fails the call with err without transferring any data.
+func IOStepErr(err error) IOStep {
+	return IOStep{kind: ioStepErr, err: err}
+}
+
+// IOStepResult transfers at most n bytes and then returns err with the
+// count actually transferred. A nil err does not hide an error from the
+// call itself (such as io.EOF) which is returned instead.
+func IOStepResult(n int, err error) IOStep {
+	return IOStep{kind: ioStepResult, n: max(n, 0), err: err}
+}
+
+// String implements fmt.Stringer.
+func (step IOStep) String() string {
+	switch step.kind {
+	case ioStepShort:
+		return fmt.Sprint("Short(", step.n, ")")
+	case ioStepErr:
+		return fmt.Sprint("Err(", step.err, ")")
+	case ioStepResult:
+		return fmt.Sprint("Result(", step.n, ", ", step.err, ")")
+	case ioStepFixed:
+		return fmt.Sprint("Fixed(", step.n, ", ", step.err, ")")
+	case ioStepOK:
+	}
+
+	return "OK"
+}
+
+// apply runs op against a buffer limited as described by the step.
+func (step IOStep) apply(
+	buf []byte, op func([]byte) (int, error),
+) (int, error) {
+	switch step.kind {
+	case ioStepErr:
+		return 0, step.err
+	case ioStepFixed:
+		return step.n, step.err
+	case ioStepOK, ioStepShort, ioStepResult:
+	}
+
+	if step.kind != ioStepOK && step.n < len(buf) {
+		buf = buf[:step.n]
+	}
+
+	n, err := op(buf)
+	if step.kind == ioStepResult && step.err != nil {
+		err = step.err
+	}
+
+	return n, err
+}
+
+func unusedSteps(op string, steps []IOStep) string {
+	if len(steps) == 0 {
+		return ""
+	}
+
+	list := make([]string, len(steps))
+	for i, step := range steps {
+		list[i] = step.String()
+	}
+
+	return fmt.Sprint(
+		len(steps), " scheduled ", op, " steps never used: [",
+		strings.Join(list, " "), "]",
+	)
+}
+
+// unusedSchedule lists the scheduled read and write steps not yet consumed.
+func (f *FakeIO) unusedSchedule() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []string
+
+	if msg := unusedSteps("Read", f.rSchedule); msg != "" {
+		result = append(result, msg)
+	}
+
+	if msg := unusedSteps("Write", f.wSchedule); msg != "" {
+		result = append(result, msg)
+	}
+
+	return result
+}
+
+// SetReadSchedule installs the outcomes of the next len(steps) Read calls,
+// replacing any schedule already present. Each call consumes one step;
+// once the schedule is exhausted reads behave normally. Errors primed with
+// SetReadError take precedence and do not consume a step.
+func (f *FakeIO) SetReadSchedule(steps ...IOStep) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rSchedule = append([]IOStep{}, steps...)
+}
+
+// SetWriteSchedule installs the outcomes of the next len(steps) Write
+// calls as described for SetReadSchedule.
+func (f *FakeIO) SetWriteSchedule(steps ...IOStep) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.wSchedule = append([]IOStep{}, steps...)
+}
+
+// verifyFakeIO arranges for the schedule and strict protocol checks of
+// chk's own fake to be verified at Release.
+func (chk *Chk) verifyFakeIO() {
+	chk.t.Helper()
+
+	if chk.fakeIOVerified {
+		return
+	}
+
+	chk.fakeIOVerified = true
+
+	chk.PushPreReleaseFunc(func() error {
+		chk.t.Helper()
+
+		if chk.faultCount == 0 {
+			for _, msg := range chk.fakeIO.unusedSchedule() {
+				chk.Error(chk.fakeIO.name, ": ", msg)
+			}
+
+			for _, violation := range chk.fakeIO.violations() {
+				chk.Error(chk.fakeIO.name, ": protocol violation: ", violation)
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetReadSchedule installs the outcomes of the next len(steps) calls to
+// chk.Read, for example:
+//
+//	chk.SetReadSchedule(
+//		sztest.IOStepShort(3),
+//		sztest.IOStepErr(errTemporary),
+//		sztest.IOStepOK(),
+//		sztest.IOStepErr(io.ErrUnexpectedEOF),
+//	)
+//
+// Steps never used are reported by chk.Release().
+func (chk *Chk) SetReadSchedule(steps ...IOStep) {
+	chk.t.Helper()
+
+	chk.verifyFakeIO()
+	chk.fakeIO.SetReadSchedule(steps...)
+}
+
+// SetWriteSchedule installs the outcomes of the next len(steps) calls to
+// chk.Write. Steps never used are reported by chk.Release().
+func (chk *Chk) SetWriteSchedule(steps ...IOStep) {
+	chk.t.Helper()
+
+	chk.verifyFakeIO()
+	chk.fakeIO.SetWriteSchedule(steps...)
+}
@@ -0,0 +1,195 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func tstChkIoSchedule(t *testing.T) {
+	t.Run("ReadSchedule", chkIoScheduleTestRead)
+	t.Run("WriteSchedule", chkIoScheduleTestWrite)
+	t.Run("ChkSchedule", chkIoScheduleTestChk)
+	t.Run("ReadPrecedence", chkIoScheduleTestReadPrecedence)
+	t.Run("ResultNilErr", chkIoScheduleTestResultNilErr)
+	t.Run("StepString", chkIoScheduleTestStepString)
+	t.Run("Unused", chkIoScheduleTestUnused)
+}
+
+func chkIoScheduleTestRead(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	errTemporary := errors.New("temporary")
+
+	fake := chk.NewFakeReader("abcdefghij")
+	fake.SetReadSchedule(
+		IOStepShort(3),
+		IOStepErr(errTemporary),
+		IOStepOK(),
+		IOStepResult(2, io.ErrUnexpectedEOF),
+	)
+
+	buf := make([]byte, 4)
+
+	n, err := fake.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "abc")
+
+	n, err = fake.Read(buf)
+	chk.Err(err, "temporary")
+	chk.Int(n, 0)
+
+	n, err = fake.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "defg")
+
+	n, err = fake.Read(buf)
+	chk.Err(err, io.ErrUnexpectedEOF.Error())
+	chk.Str(string(buf[:n]), "hi")
+
+	n, err = fake.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "j")
+}
+
+func chkIoScheduleTestWrite(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeWriter()
+	fake.SetWriteSchedule(
+		IOStepShort(2),
+		IOStepErr(errors.New("disk busy")),
+	)
+
+	n, err := fake.Write([]byte("hello"))
+	chk.NoErr(err)
+	chk.Int(n, 2)
+
+	n, err = fake.Write([]byte("llo"))
+	chk.Err(err, "disk busy")
+	chk.Int(n, 0)
+
+	n, err = fake.Write([]byte("llo"))
+	chk.NoErr(err)
+	chk.Int(n, 3)
+
+	chk.Str(string(fake.GetIOWriterData()), "hello")
+}
+
+func chkIoScheduleTestChk(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.SetIOReaderData("abcdef")
+	chk.SetReadSchedule(IOStepShort(1), IOStepShort(2))
+	chk.SetWriteSchedule(IOStepShort(1))
+
+	var dst bytes.Buffer
+
+	n, err := io.Copy(&dst, chk)
+	chk.NoErr(err)
+	chk.Int64(n, 6)
+	chk.Str(dst.String(), "abcdef")
+
+	_, err = io.Copy(chk, bytes.NewBufferString("xyz"))
+	chk.Err(err, io.ErrShortWrite.Error())
+}
+
+func chkIoScheduleTestReadPrecedence(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeReader("abc")
+	fake.SetReadSchedule(IOStepShort(1))
+	fake.SetReadError(0, errors.New("primed"))
+
+	buf := make([]byte, 10)
+
+	_, err := fake.Read(buf)
+	chk.Err(err, "primed")
+
+	n, err := fake.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "a")
+}
+
+func chkIoScheduleTestResultNilErr(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	errBoom := errors.New("boom")
+
+	fake := chk.NewFakeReader("ab")
+	fake.SetIOReaderError(1, errBoom)
+	fake.SetReadSchedule(
+		IOStepResult(4, nil),
+		IOStepResult(4, nil),
+	)
+
+	buf := make([]byte, 4)
+
+	n, err := fake.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "a")
+
+	n, err = fake.Read(buf)
+	chk.Err(err, "boom")
+	chk.Int(n, 0)
+}
+
+func chkIoScheduleTestStepString(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.Str(IOStepOK().String(), "OK")
+	chk.Str(IOStepShort(3).String(), "Short(3)")
+	chk.Str(IOStepShort(-3).String(), "Short(0)")
+	chk.Str(IOStepErr(io.EOF).String(), "Err(EOF)")
+	chk.Str(IOStepResult(1, io.EOF).String(), "Result(1, EOF)")
+}
+
+func chkIoScheduleTestUnused(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	orig := chk.FailFast(false)
+	defer chk.FailFast(orig)
+
+	chk.SetReadSchedule(IOStepOK(), IOStepErr(io.EOF))
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("SetReadSchedule"),
+		chkOutHelper("verifyFakeIO"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("verifyFakeIO.func1"),
+		chkOutErrorNoFail(
+			"chk: 2 scheduled Read steps never used: [OK Err(EOF)]",
+		),
+	)
+}
@@ -18,27 +18,170 @@
 
 package sztest
 
+import (
+	"fmt"
+	"io"
+)
+
+// fakeIOMaxWriteAt bounds the size WriteAt may grow the reader data to so a
+// stray large offset is reported rather than attempting a huge allocation.
+const fakeIOMaxWriteAt = 1 << 26
+
+// SetSeekError primes the fake to return the provided error on a future
+// Seek call. The error is returned once, after which normal seek behavior
+// resumes.
+func (f *FakeIO) SetSeekError(pos int64, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ioSeekErrPos = pos
+	f.ioSeekErr = err
+	f.ioSeekErrSet = true
+}
+
+// Seek implements the io.Seeker interface over the reader data set with
+// SetIOReaderData. The next Read continues from the new offset, which may
+// lie beyond the end of the data (reads there return io.EOF). A pending
+// error set via SetSeekError is returned instead without moving.
+func (f *FakeIO) Seek(offset int64, whence int) (int64, error) {
+	pos, err := f.seek(offset, whence)
+	f.observeSeek(err)
+	f.recordCall(
+		fmt.Sprint("Seek(", offset, ", ", whenceName(whence), ")"), pos, err,
+	)
+
+	return pos, err
+}
+
+func (f *FakeIO) seek(offset int64, whence int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.ioSeekErrSet {
+		seekPos := f.ioSeekErrPos
+		seekErr := f.ioSeekErr
+		f.ioSeekErrPos = 0
+		f.ioSeekErr = nil
+		f.ioSeekErrSet = false
+
+		return seekPos, seekErr
+	}
+
+	var newPos int64
+
+	switch whence {
+	case io.SeekStart:
+		newPos = offset
+	case io.SeekCurrent:
+		newPos = int64(f.rPos) + offset
+	case io.SeekEnd:
+		newPos = int64(len(f.rData)) + offset
+	default:
+		return int64(f.rPos), fmt.Errorf("%w: whence %d", ErrInvalidSeek, whence)
+	}
+
+	if newPos < 0 {
+		return int64(f.rPos),
+			fmt.Errorf("%w: negative position %d", ErrInvalidSeek, newPos)
+	}
+
+	f.rPos = int(newPos)
+	f.rLeft = max(len(f.rData)-f.rPos, 0)
+
+	if f.rErr == ErrReadPastEndOfData {
+		f.rErr = nil
+	}
+
+	return newPos, nil
+}
+
+// ReadAt implements io.ReaderAt over the reader data set with
+// SetIOReaderData. It does not affect the offset used by Read.
+func (f *FakeIO) ReadAt(dataBuf []byte, offset int64) (int, error) {
+	n, err := f.readAt(dataBuf, offset)
+	f.recordCall(fmt.Sprint("ReadAt(", len(dataBuf), ", ", offset, ")"), n, err)
+
+	return n, err
+}
+
+func (f *FakeIO) readAt(dataBuf []byte, offset int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if offset < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidSeek, offset)
+	}
+
+	if offset >= int64(len(f.rData)) {
+		return 0, io.EOF
+	}
+
+	n := copy(dataBuf, f.rData[offset:])
+	if n < len(dataBuf) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// WriteAt implements io.WriterAt updating the reader data set with
+// SetIOReaderData, extending it with zero bytes as required. Writes ending
+// beyond 64 MiB are rejected with ErrInvalidSeek. It does not affect the
+// offset used by Read or the data recorded by Write.
+func (f *FakeIO) WriteAt(data []byte, offset int64) (int, error) {
+	n, err := f.writeAt(data, offset)
+	f.recordCall(fmt.Sprint("WriteAt(", len(data), ", ", offset, ")"), n, err)
+
+	return n, err
+}
+
+func (f *FakeIO) writeAt(data []byte, offset int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if offset < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidSeek, offset)
+	}
+
+	if offset > int64(fakeIOMaxWriteAt-len(data)) {
+		return 0, fmt.Errorf(
+			"%w: offset %d exceeds %d byte limit",
+			ErrInvalidSeek, offset, fakeIOMaxWriteAt,
+		)
+	}
+
+	end := int(offset) + len(data)
+	if end > len(f.rData) {
+		f.rData = append(f.rData, make([]byte, end-len(f.rData))...)
+	}
+
+	copy(f.rData[offset:], data)
+
+	f.rLeft = max(len(f.rData)-f.rPos, 0)
+
+	return len(data), nil
+}
+
 // SetSeekError primes the chk object to return the provided error on a
 // future Seek call. The error is returned once, after which normal seek
 // behavior resumes.
 func (chk *Chk) SetSeekError(pos int64, err error) {
-	chk.ioSeekErrPos = pos
-	chk.ioSeekErr = err
-	chk.ioSeekErrSet = true
+	chk.fakeIO.SetSeekError(pos, err)
 }
 
-// Seek implements the io.Seeker interface. It updates the current seek
-// position and returns any pending error set via SetSeekError. If no
-// error is pending, it behaves as a successful seek.
-func (chk *Chk) Seek(_ int64, _ int) (int64, error) {
-	seekPos := chk.ioSeekErrPos
-	seekErr := chk.ioSeekErr
+// Seek implements the io.Seeker interface over the data set with
+// SetIOReaderData. A pending error set via SetSeekError is returned
+// instead without moving.
+func (chk *Chk) Seek(offset int64, whence int) (int64, error) {
+	return chk.fakeIO.Seek(offset, whence)
+}
 
-	if chk.ioSeekErrSet {
-		chk.ioSeekErrPos = 0
-		chk.ioSeekErr = nil
-		chk.ioSeekErrSet = false
-	}
+// ReadAt implements io.ReaderAt over the data set with SetIOReaderData.
+func (chk *Chk) ReadAt(dataBuf []byte, offset int64) (int, error) {
+	return chk.fakeIO.ReadAt(dataBuf, offset)
+}
 
-	return seekPos, seekErr
+// WriteAt implements io.WriterAt over the data set with SetIOReaderData.
+func (chk *Chk) WriteAt(data []byte, offset int64) (int, error) {
+	return chk.fakeIO.WriteAt(data, offset)
 }
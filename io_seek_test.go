@@ -26,6 +26,11 @@ import (
 
 func tstChkIoSeek(t *testing.T) {
 	t.Run("SeekError", chkIOSeekTestSetSeekError)
+	t.Run("Whence", chkIOSeekTestWhence)
+	t.Run("Invalid", chkIOSeekTestInvalid)
+	t.Run("ReadAfterEOF", chkIOSeekTestReadAfterEOF)
+	t.Run("ReadAt", chkIOSeekTestReadAt)
+	t.Run("WriteAt", chkIOSeekTestWriteAt)
 }
 
 func chkIOSeekTestSetSeekError(t *testing.T) {
@@ -33,7 +38,7 @@ func chkIOSeekTestSetSeekError(t *testing.T) {
 	defer chk.Release()
 
 	newPos, err := chk.Seek(2, io.SeekStart)
-	chk.Int64(newPos, 0)
+	chk.Int64(newPos, 2)
 	chk.NoErr(err)
 
 	chk.SetSeekError(24, errors.New("the seek error"))
@@ -41,3 +46,136 @@ func chkIOSeekTestSetSeekError(t *testing.T) {
 	chk.Int64(newPos, 24)
 	chk.Err(err, "the seek error")
 }
+
+func chkIOSeekTestWhence(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.SetIOReaderData("0123456789")
+
+	buf := make([]byte, 3)
+
+	newPos, err := chk.Seek(4, io.SeekStart)
+	chk.NoErr(err)
+	chk.Int64(newPos, 4)
+
+	n, err := chk.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "456")
+
+	newPos, err = chk.Seek(-5, io.SeekCurrent)
+	chk.NoErr(err)
+	chk.Int64(newPos, 2)
+
+	n, err = chk.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "234")
+
+	newPos, err = chk.Seek(-2, io.SeekEnd)
+	chk.NoErr(err)
+	chk.Int64(newPos, 8)
+
+	n, err = chk.Read(buf)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "89")
+}
+
+func chkIOSeekTestInvalid(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeReader("abc")
+
+	newPos, err := fake.Seek(1, io.SeekStart)
+	chk.NoErr(err)
+	chk.Int64(newPos, 1)
+
+	newPos, err = fake.Seek(-2, io.SeekCurrent)
+	chk.Err(err, "invalid seek: negative position -1")
+	chk.True(errors.Is(err, ErrInvalidSeek))
+	chk.Int64(newPos, 1)
+
+	newPos, err = fake.Seek(0, 99)
+	chk.Err(err, "invalid seek: whence 99")
+	chk.Int64(newPos, 1)
+}
+
+func chkIOSeekTestReadAfterEOF(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeReader("abc")
+
+	data, err := io.ReadAll(fake)
+	chk.NoErr(err)
+	chk.Str(string(data), "abc")
+
+	_, err = fake.Seek(0, io.SeekStart)
+	chk.NoErr(err)
+
+	data, err = io.ReadAll(fake)
+	chk.NoErr(err)
+	chk.Str(string(data), "abc")
+
+	_, err = fake.Seek(10, io.SeekStart)
+	chk.NoErr(err)
+
+	data, err = io.ReadAll(fake)
+	chk.NoErr(err)
+	chk.Str(string(data), "")
+}
+
+func chkIOSeekTestReadAt(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	var readerAt io.ReaderAt = chk.NewFakeReader("0123456789")
+
+	buf := make([]byte, 4)
+
+	n, err := readerAt.ReadAt(buf, 3)
+	chk.NoErr(err)
+	chk.Str(string(buf[:n]), "3456")
+
+	n, err = readerAt.ReadAt(buf, 8)
+	chk.Err(err, io.EOF.Error())
+	chk.Str(string(buf[:n]), "89")
+
+	n, err = readerAt.ReadAt(buf, 10)
+	chk.Err(err, io.EOF.Error())
+	chk.Int(n, 0)
+
+	_, err = readerAt.ReadAt(buf, -1)
+	chk.Err(err, "invalid seek: negative offset -1")
+
+	n, err = chk.ReadAt(buf, 0)
+	chk.Err(err, io.EOF.Error())
+	chk.Int(n, 0)
+}
+
+func chkIOSeekTestWriteAt(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	chk.SetIOReaderData("abc")
+
+	n, err := chk.WriteAt([]byte("XY"), 1)
+	chk.NoErr(err)
+	chk.Int(n, 2)
+
+	n, err = chk.WriteAt([]byte("Z"), 5)
+	chk.NoErr(err)
+	chk.Int(n, 1)
+
+	_, err = chk.WriteAt([]byte("Z"), -1)
+	chk.Err(err, "invalid seek: negative offset -1")
+
+	_, err = chk.WriteAt([]byte("Z"), 1<<40)
+	chk.Err(err, "invalid seek: offset 1099511627776 exceeds 67108864 byte limit")
+
+	data, err := io.ReadAll(chk)
+	chk.NoErr(err)
+	chk.Str(string(data), "aXY\x00\x00Z")
+
+	chk.Str(string(chk.GetIOWriterData()), "")
+}
@@ -0,0 +1,170 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// StdinStream is the writing side of a replacement os.Stdin created by
+// chk.SetStdinStream. Data written to it becomes readable from os.Stdin,
+// allowing a test to feed input progressively and to exercise code that
+// blocks waiting for input. All methods are safe for concurrent use.
+type StdinStream struct {
+	mu          sync.Mutex
+	rPipe       *os.File
+	wPipe       *os.File
+	writeClosed bool
+	readClosed  bool
+	readFailing bool
+}
+
+// Write sends data to os.Stdin clearing any failure set by FailRead. As the
+// stream is backed by an os.Pipe a write larger than the pipe buffer blocks
+// until the code under test reads enough of it.
+func (s *StdinStream) Write(data []byte) (int, error) {
+	err := s.resumeRead()
+	if err != nil {
+		return 0, err
+	}
+
+	return s.wPipe.Write(data) //nolint:wrapcheck // Ok.
+}
+
+// WriteString sends the string to os.Stdin as with Write.
+func (s *StdinStream) WriteString(data string) (int, error) {
+	return s.Write([]byte(data))
+}
+
+// FailRead makes the next read of os.Stdin (including one already blocked
+// waiting for data) fail with an error wrapping os.ErrDeadlineExceeded.
+// Reads keep failing until the next Write, WriteString or Close, after
+// which delivery resumes with no data lost. As os.Stdin must remain an
+// *os.File the error returned cannot be chosen.
+func (s *StdinStream) FailRead() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.readClosed {
+		return os.ErrClosed
+	}
+
+	s.readFailing = true
+
+	return s.rPipe.SetReadDeadline(time.Unix(1, 0)) //nolint:wrapcheck // Ok.
+}
+
+// resumeRead clears a failure set by FailRead.
+func (s *StdinStream) resumeRead() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.readFailing || s.readClosed {
+		return nil
+	}
+
+	s.readFailing = false
+
+	return s.rPipe.SetReadDeadline(time.Time{}) //nolint:wrapcheck // Ok.
+}
+
+// Close closes the writing side of the stream so that once all data
+// already written has been read os.Stdin returns io.EOF. Any failure set by
+// FailRead is cleared. Closing more than once is harmless.
+func (s *StdinStream) Close() error {
+	err := s.resumeRead()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writeClosed {
+		return nil
+	}
+
+	s.writeClosed = true
+
+	return s.wPipe.Close() //nolint:wrapcheck // Ok.
+}
+
+// CloseRead closes the reading side of the stream so that the next read of
+// os.Stdin (including one already blocked waiting for data) fails with an
+// error wrapping os.ErrClosed. Unlike FailRead this is permanent: os.Stdin
+// cannot be read again for the rest of the test, every later read failing
+// the same way, and data written afterwards is never delivered. Closing
+// more than once is harmless.
+func (s *StdinStream) CloseRead() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.readClosed {
+		return nil
+	}
+
+	s.readClosed = true
+
+	return s.rPipe.Close() //nolint:wrapcheck // Ok.
+}
+
+// release closes any side of the pipe still open.
+func (s *StdinStream) release() error {
+	err := s.Close()
+
+	if rErr := s.CloseRead(); err == nil {
+		err = rErr
+	}
+
+	return err
+}
+
+// SetStdinStream replaces os.Stdin with the reading side of a pipe and
+// returns the writing side. Unlike SetStdinData the stream stays open until
+// explicitly closed, letting the test push data over time, close it to
+// signal io.EOF, make reads fail until more data is written with FailRead
+// or make all further reads fail with CloseRead. The original os.Stdin is
+// restored and the pipe closed when chk.Release() is called.
+func (chk *Chk) SetStdinStream() *StdinStream {
+	chk.t.Helper()
+
+	rPipe, wPipe, err := os.Pipe()
+	if !chk.NoErr(err) {
+		return nil
+	}
+
+	stream := &StdinStream{
+		rPipe: rPipe,
+		wPipe: wPipe,
+	}
+
+	origStdin := os.Stdin
+
+	chk.PushPostReleaseFunc(func() error {
+		os.Stdin = origStdin
+
+		return stream.release()
+	})
+
+	os.Stdin = rPipe
+
+	return stream
+}
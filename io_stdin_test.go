@@ -0,0 +1,168 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func tstChkIoStdin(t *testing.T) {
+	t.Run("Progressive", chkIoStdinTestProgressive)
+	t.Run("EOF", chkIoStdinTestEOF)
+	t.Run("FailRead", chkIoStdinTestFailRead)
+	t.Run("CloseRead", chkIoStdinTestCloseRead)
+	t.Run("Restored", chkIoStdinTestRestored)
+}
+
+func chkIoStdinTestProgressive(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	stream := chk.SetStdinStream()
+
+	lines := make(chan string)
+	done := make(chan error)
+
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+
+		done <- scanner.Err()
+	}()
+
+	_, err := stream.WriteString("first\n")
+	chk.NoErr(err)
+	chk.Str(<-lines, "first")
+
+	_, err = stream.Write([]byte("second\n"))
+	chk.NoErr(err)
+	chk.Str(<-lines, "second")
+
+	chk.NoErr(stream.Close())
+	chk.NoErr(<-done)
+}
+
+func chkIoStdinTestEOF(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	stream := chk.SetStdinStream()
+
+	_, err := stream.WriteString("all of it")
+	chk.NoErr(err)
+	chk.NoErr(stream.Close())
+	chk.NoErr(stream.Close())
+
+	data, err := io.ReadAll(os.Stdin)
+	chk.NoErr(err)
+	chk.Str(string(data), "all of it")
+}
+
+func chkIoStdinTestFailRead(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	stream := chk.SetStdinStream()
+	stdin := os.Stdin
+
+	result := make(chan error)
+
+	go func() {
+		_, err := stdin.Read(make([]byte, 10))
+		result <- err
+	}()
+
+	chk.NoErr(stream.FailRead())
+	chk.True(errors.Is(<-result, os.ErrDeadlineExceeded))
+
+	_, err := stream.WriteString("kept ")
+	chk.NoErr(err)
+
+	chk.NoErr(stream.FailRead())
+
+	_, err = stdin.Read(make([]byte, 10))
+	chk.True(errors.Is(err, os.ErrDeadlineExceeded))
+
+	_, err = stream.WriteString("data")
+	chk.NoErr(err)
+	chk.NoErr(stream.FailRead())
+	chk.NoErr(stream.Close())
+
+	data, err := io.ReadAll(stdin)
+	chk.NoErr(err)
+	chk.Str(string(data), "kept data")
+
+	chk.NoErr(stream.CloseRead())
+	chk.Err(stream.FailRead(), os.ErrClosed.Error())
+}
+
+func chkIoStdinTestCloseRead(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	stream := chk.SetStdinStream()
+	stdin := os.Stdin
+
+	result := make(chan error)
+
+	go func() {
+		_, err := stdin.Read(make([]byte, 10))
+		result <- err
+	}()
+
+	chk.NoErr(stream.CloseRead())
+
+	err := <-result
+	chk.True(errors.Is(err, os.ErrClosed))
+
+	_, err = stdin.Read(make([]byte, 10))
+	chk.True(errors.Is(err, os.ErrClosed))
+
+	_, _ = stream.WriteString("late\n")
+
+	_, err = stdin.Read(make([]byte, 10))
+	chk.True(errors.Is(err, os.ErrClosed))
+
+	chk.NoErr(stream.CloseRead())
+}
+
+func chkIoStdinTestRestored(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	origStdin := os.Stdin
+
+	inner := CaptureNothing(t)
+	stream := inner.SetStdinStream()
+
+	chk.True(os.Stdin != origStdin)
+
+	inner.Release()
+
+	chk.True(os.Stdin == origStdin)
+
+	_, err := stream.WriteString("after release")
+	chk.True(errors.Is(err, os.ErrClosed))
+}
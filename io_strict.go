@@ -0,0 +1,138 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Strict enables protocol checking on the fake. The following misuse by
+// the code under test is then reported as a failure by chk.Release():
+//
+//   - the fake is never closed
+//   - Read is called again after it has returned io.EOF
+//   - Read or Write is called after Close
+//   - Close is called more than once
+func (f *FakeIO) Strict() {
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	f.strict = true
+}
+
+// misuse records a protocol violation. The caller must hold logMu.
+func (f *FakeIO) misuse(violation string) {
+	if f.misuseCount == nil {
+		f.misuseCount = make(map[string]int)
+	}
+
+	if f.misuseCount[violation] == 0 {
+		f.misuseOrder = append(f.misuseOrder, violation)
+	}
+
+	f.misuseCount[violation]++
+}
+
+// observeRead tracks a completed Read call for strict checking.
+func (f *FakeIO) observeRead(err error) {
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	if f.closeCount > 0 {
+		f.misuse("Read after Close")
+	}
+
+	if f.eofSeen {
+		f.misuse("Read after io.EOF")
+	}
+
+	if errors.Is(err, io.EOF) {
+		f.eofSeen = true
+	}
+}
+
+// observeSeek tracks a successful Seek which permits reading again after
+// io.EOF.
+func (f *FakeIO) observeSeek(err error) {
+	if err != nil {
+		return
+	}
+
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	f.eofSeen = false
+}
+
+// observeWrite tracks a completed Write call for strict checking.
+func (f *FakeIO) observeWrite() {
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	if f.closeCount > 0 {
+		f.misuse("Write after Close")
+	}
+}
+
+// observeClose tracks a completed Close call for strict checking.
+func (f *FakeIO) observeClose() {
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	if f.closeCount > 0 {
+		f.misuse("Close after Close")
+	}
+
+	f.closeCount++
+}
+
+// violations lists the protocol violations observed when strict.
+func (f *FakeIO) violations() []string {
+	f.logMu.Lock()
+	defer f.logMu.Unlock()
+
+	if !f.strict {
+		return nil
+	}
+
+	var result []string
+
+	if f.closeCount == 0 {
+		result = append(result, "never closed")
+	}
+
+	for _, violation := range f.misuseOrder {
+		result = append(result, fmt.Sprint(
+			violation, " (", f.misuseCount[violation], " times)",
+		))
+	}
+
+	return result
+}
+
+// StrictIO enables protocol checking on chk's own io methods as described
+// for FakeIO.Strict.
+func (chk *Chk) StrictIO() {
+	chk.t.Helper()
+
+	chk.verifyFakeIO()
+	chk.fakeIO.Strict()
+}
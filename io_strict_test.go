@@ -0,0 +1,159 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"io"
+	"testing"
+)
+
+func tstChkIoStrict(t *testing.T) {
+	t.Run("Clean", chkIoStrictTestClean)
+	t.Run("NotStrict", chkIoStrictTestNotStrict)
+	t.Run("Violations", chkIoStrictTestViolations)
+	t.Run("NeverClosed", chkIoStrictTestNeverClosed)
+	t.Run("Conn", chkIoStrictTestConn)
+}
+
+func chkIoStrictTestClean(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeReader("data")
+	fake.Strict()
+
+	data, err := io.ReadAll(fake)
+	chk.NoErr(err)
+	chk.Str(string(data), "data")
+
+	_, err = fake.Seek(0, io.SeekStart)
+	chk.NoErr(err)
+
+	data, err = io.ReadAll(fake)
+	chk.NoErr(err)
+	chk.Str(string(data), "data")
+
+	chk.NoErr(fake.Close())
+}
+
+func chkIoStrictTestNotStrict(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	fake := chk.NewFakeReader("data")
+
+	_, _ = io.ReadAll(fake)
+	_, _ = io.ReadAll(fake)
+	_ = fake.Close()
+	_ = fake.Close()
+	_, _ = fake.Write([]byte("x"))
+}
+
+func chkIoStrictTestViolations(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	orig := chk.FailFast(false)
+	defer chk.FailFast(orig)
+
+	chk.StrictIO()
+	chk.SetIOReaderData("abc")
+
+	buf := make([]byte, 10)
+
+	_, _ = chk.Read(buf)
+	_, _ = chk.Read(buf)
+	_, _ = chk.Read(buf)
+	_ = chk.Close()
+	_, _ = chk.Read(buf)
+	_, _ = chk.Write(buf)
+	_ = chk.Close()
+	_ = chk.Close()
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("StrictIO"),
+		chkOutHelper("verifyFakeIO"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("verifyFakeIO.func1"),
+		chkOutErrorNoFail("chk: protocol violation: Read after io.EOF (2 times)"),
+		chkOutErrorNoFail("chk: protocol violation: Read after Close (1 times)"),
+		chkOutErrorNoFail("chk: protocol violation: Write after Close (1 times)"),
+		chkOutErrorNoFail("chk: protocol violation: Close after Close (2 times)"),
+	)
+}
+
+func chkIoStrictTestNeverClosed(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	fake := chk.NewFakeWriter()
+	fake.Strict()
+
+	_, _ = fake.Write([]byte("abc"))
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("NewFakeWriter"),
+		chkOutHelper("newFake"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("newFake.func1"),
+		chkOutHelper("reportUnused"),
+		chkOutError("FakeWriter#1: protocol violation: never closed"),
+	)
+}
+
+func chkIoStrictTestConn(t *testing.T) {
+	iT := new(iTst)
+	chk := CaptureNothing(iT)
+	iT.chk = chk
+
+	client, server := chk.NewFakeConnPair()
+	client.Strict()
+
+	_ = server.Close()
+	_ = client.Close()
+	_, _ = client.Write([]byte("x"))
+
+	chk.Release()
+	iT.check(t,
+		chkOutCapture("Nothing"),
+		chkOutHelper("NewFakeConnPair"),
+		chkOutHelper("newFake"),
+		chkOutPush("Pre", ""),
+		chkOutHelper("newFake"),
+		chkOutPush("Pre", ""),
+		chkOutRelease(),
+		chkOutPush("Pre", "func2"),
+		chkOutHelper("newFake.func1"),
+		chkOutHelper("reportUnused"),
+		chkOutPush("Pre", "func1"),
+		chkOutHelper("newFake.func1"),
+		chkOutHelper("reportUnused"),
+		chkOutError("FakeConn#1: protocol violation: Write after Close (1 times)"),
+	)
+}
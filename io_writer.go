@@ -18,70 +18,148 @@
 
 package sztest
 
-// GetIOWriterData returns all bytes written to the io.Writer interface
-// so far, and clears the internal buffer. This is useful for verifying
-// output in tests.
-func (chk *Chk) GetIOWriterData() []byte {
-	data := chk.wData
+import "fmt"
 
-	chk.wData = []byte{}
+// GetIOWriterData returns all bytes written to the fake io.Writer so far,
+// and clears the internal buffer.
+func (f *FakeIO) GetIOWriterData() []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data := f.wData
+
+	f.wData = []byte{}
 
 	return data
 }
 
-// SetIOWriterError configures the io.Writer interface to return the
-// specified error after writing n bytes. Once triggered, the error is
-// cleared and subsequent writes proceed as normal unless another error
-// is set.
-func (chk *Chk) SetIOWriterError(n int, err error) {
-	chk.wErrPos = n
-	chk.wErr = err
-	chk.wData = make([]byte, 0)
+// SetIOWriterError configures the fake io.Writer to return the specified
+// error after writing n bytes.
+func (f *FakeIO) SetIOWriterError(n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.wErrPos = n
+	f.wErr = err
+	f.wErrHit = false
+	f.wData = make([]byte, 0)
 }
 
-// SetWriteError primes the chk object to return the given position and
-// error on the very next Write call. The error is returned once, and then
-// cleared automatically.
-func (chk *Chk) SetWriteError(pos int, err error) {
-	chk.ioWriteErrPos = pos
-	chk.ioWriteErr = err
-	chk.ioWriteErrSet = true
+// SetWriteError primes the fake to return the given position and error on
+// the very next Write call. The error is returned once, and then cleared
+// automatically.
+func (f *FakeIO) SetWriteError(pos int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ioWriteErrPos = pos
+	f.ioWriteErr = err
+	f.ioWriteErrSet = true
 }
 
 // Write implements the io.Writer interface. Data is recorded internally
 // and can be retrieved via GetIOWriterData. Pending errors set with
-// SetIOWriterError or SetWriteError take precedence and are returned
-// according to their rules.
-func (chk *Chk) Write(data []byte) (int, error) {
-	if chk.ioWriteErrSet {
-		writePos := chk.ioWriteErrPos
-		writeErr := chk.ioWriteErr
-		chk.ioWriteErrPos = 0
-		chk.ioWriteErr = nil
-		chk.ioWriteErrSet = false
-
-		return writePos, writeErr
+// SetIOWriterError, SetWriteError or SetWriteSchedule take precedence and
+// are returned according to their rules.
+func (f *FakeIO) Write(data []byte) (int, error) {
+	n, err := f.writeNext(data)
+	f.pace(n)
+	f.observeWrite()
+	f.recordCall(fmt.Sprint("Write(", len(data), ")"), n, err)
+
+	return n, err
+}
+
+func (f *FakeIO) writeNext(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if step, ok := f.takeWrite(); ok {
+		return step.apply(data, f.write)
+	}
+
+	return f.write(data)
+}
+
+// takeWrite returns the outcome injected for the next Write call, if any,
+// consuming it: first an error primed by SetWriteError and then the next
+// step scheduled by SetWriteSchedule. The caller must hold the lock.
+func (f *FakeIO) takeWrite() (IOStep, bool) {
+	if f.ioWriteErrSet {
+		step := IOStep{kind: ioStepFixed, n: f.ioWriteErrPos, err: f.ioWriteErr}
+		f.ioWriteErrPos = 0
+		f.ioWriteErr = nil
+		f.ioWriteErrSet = false
+
+		return step, true
+	}
+
+	if len(f.wSchedule) > 0 {
+		step := f.wSchedule[0]
+		f.wSchedule = f.wSchedule[1:]
+
+		return step, true
 	}
 
+	return IOStep{}, false
+}
+
+// write records data honoring SetIOWriterError. The caller must hold the
+// lock.
+func (f *FakeIO) write(data []byte) (int, error) {
 	count := 0
 
-	if chk.wErr != nil && chk.wErrPos <= 0 {
-		return 0, chk.wErr
+	if f.wErr != nil && f.wErrPos <= 0 {
+		f.wErrHit = true
+
+		return 0, f.wErr
 	}
 
 	for _, nextByte := range data {
-		if chk.wErrPos == 0 {
-			if chk.wErr == nil {
+		if f.wErrPos == 0 {
+			if f.wErr == nil {
 				return count, ErrForcedOutOfSpace
 			}
 
-			return count, chk.wErr
+			f.wErrHit = true
+
+			return count, f.wErr
 		}
 
-		chk.wData = append(chk.wData, nextByte)
-		chk.wErrPos--
+		f.wData = append(f.wData, nextByte)
+		f.wErrPos--
 		count++
 	}
 
 	return count, nil
 }
+
+// GetIOWriterData returns all bytes written to the io.Writer interface
+// so far, and clears the internal buffer. This is useful for verifying
+// output in tests.
+func (chk *Chk) GetIOWriterData() []byte {
+	return chk.fakeIO.GetIOWriterData()
+}
+
+// SetIOWriterError configures the io.Writer interface to return the
+// specified error after writing n bytes. Once triggered, the error is
+// cleared and subsequent writes proceed as normal unless another error
+// is set.
+func (chk *Chk) SetIOWriterError(n int, err error) {
+	chk.fakeIO.SetIOWriterError(n, err)
+}
+
+// SetWriteError primes the chk object to return the given position and
+// error on the very next Write call. The error is returned once, and then
+// cleared automatically.
+func (chk *Chk) SetWriteError(pos int, err error) {
+	chk.fakeIO.SetWriteError(pos, err)
+}
+
+// Write implements the io.Writer interface. Data is recorded internally
+// and can be retrieved via GetIOWriterData. Pending errors set with
+// SetIOWriterError or SetWriteError take precedence and are returned
+// according to their rules.
+func (chk *Chk) Write(data []byte) (int, error) {
+	return chk.fakeIO.Write(data)
+}
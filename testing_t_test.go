@@ -36,6 +36,35 @@ type iTst struct {
 	chk    *Chk
 }
 
+// gTst is an iTst whose FailNow stops the calling goroutine (as the real
+// testing.T does) instead of returning. Use with runGoexitTest.
+type gTst struct {
+	iTst
+}
+
+func (t *gTst) FailNow() {
+	t.output += "Fail Now\n"
+
+	runtime.Goexit()
+}
+
+// runGoexitTest runs fn with a new gTst in its own goroutine, waiting until
+// it returns or is stopped by FailNow.
+func runGoexitTest(fn func(gT *gTst)) *gTst {
+	gT := new(gTst)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		fn(gT)
+	}()
+
+	<-done
+
+	return gT
+}
+
 func (t *iTst) Helper() {
 	t.output += "Helper: " + t.getCallerName() + "\n"
 }
@@ -0,0 +1,118 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"bytes"
+	"strings"
+)
+
+// txtarFile is a single named entry of a txtar archive. A name ending in
+// "/" with no data denotes an (otherwise empty) directory.
+type txtarFile struct {
+	name string
+	data []byte
+}
+
+// txtarArchive is a parsed txtar archive as described by
+// golang.org/x/tools/txtar: an optional leading comment followed by files
+// each introduced by a "-- name --" marker line.
+type txtarArchive struct {
+	comment []byte
+	files   []txtarFile
+}
+
+const (
+	txtarMarkerStart = "-- "
+	txtarMarkerEnd   = " --"
+)
+
+// txtarMarker returns the file name if line is a txtar file marker.
+func txtarMarker(line []byte) (string, bool) {
+	line = bytes.TrimRight(line, "\r\n")
+
+	if !bytes.HasPrefix(line, []byte(txtarMarkerStart)) ||
+		!bytes.HasSuffix(line, []byte(txtarMarkerEnd)) ||
+		len(line) < len(txtarMarkerStart)+len(txtarMarkerEnd)+1 {
+		return "", false
+	}
+
+	name := strings.TrimSpace(string(
+		line[len(txtarMarkerStart) : len(line)-len(txtarMarkerEnd)],
+	))
+
+	return name, name != ""
+}
+
+// txtarFixNL adds a final newline to non-empty data lacking one.
+func txtarFixNL(data []byte) []byte {
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return data
+	}
+
+	return append(data, '\n')
+}
+
+// parseTxtar splits data into its comment and files. Parsing never fails:
+// text before the first marker is the comment and every marker starts a new
+// file running to the next marker or the end of the data.
+func parseTxtar(data []byte) *txtarArchive {
+	archive := new(txtarArchive)
+
+	var (
+		current *txtarFile
+		body    []byte
+	)
+
+	flush := func() {
+		if current == nil {
+			archive.comment = txtarFixNL(body)
+		} else {
+			current.data = txtarFixNL(body)
+			archive.files = append(archive.files, *current)
+		}
+
+		body = nil
+	}
+
+	for len(data) > 0 {
+		line := data
+
+		idx := bytes.IndexByte(data, '\n')
+		if idx >= 0 {
+			line = data[:idx+1]
+		}
+
+		data = data[len(line):]
+
+		if name, ok := txtarMarker(line); ok {
+			flush()
+
+			current = &txtarFile{name: name}
+
+			continue
+		}
+
+		body = append(body, line...)
+	}
+
+	flush()
+
+	return archive
+}
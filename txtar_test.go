@@ -0,0 +1,91 @@
+/*
+   Golang test helper library: sztest.
+   Copyright (C) 2023-2025 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package sztest
+
+import (
+	"testing"
+)
+
+func tstTxtar(t *testing.T) {
+	t.Run("Parse", txtarTestParse)
+	t.Run("NoComment", txtarTestNoComment)
+	t.Run("MissingNewline", txtarTestMissingNewline)
+	t.Run("NotMarkers", txtarTestNotMarkers)
+}
+
+func txtarTestParse(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	archive := parseTxtar([]byte("" +
+		"A comment\n" +
+		"-- a.txt --\n" +
+		"line 1\n" +
+		"line 2\n" +
+		"-- dir/b.txt --\n" +
+		"-- empty/ --\n",
+	))
+
+	chk.Str(string(archive.comment), "A comment\n")
+	chk.Int(len(archive.files), 3)
+	chk.Str(archive.files[0].name, "a.txt")
+	chk.Str(string(archive.files[0].data), "line 1\nline 2\n")
+	chk.Str(archive.files[1].name, "dir/b.txt")
+	chk.Str(string(archive.files[1].data), "")
+	chk.Str(archive.files[2].name, "empty/")
+}
+
+func txtarTestNoComment(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	archive := parseTxtar([]byte("-- a.txt --\ndata\n"))
+
+	chk.Str(string(archive.comment), "")
+	chk.Int(len(archive.files), 1)
+	chk.Str(string(archive.files[0].data), "data\n")
+}
+
+func txtarTestMissingNewline(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	archive := parseTxtar([]byte("comment\n-- a.txt --\ndata"))
+
+	chk.Str(string(archive.comment), "comment\n")
+	chk.Str(string(archive.files[0].data), "data\n")
+}
+
+func txtarTestNotMarkers(t *testing.T) {
+	chk := CaptureNothing(t)
+	defer chk.Release()
+
+	archive := parseTxtar([]byte("" +
+		"-- a.txt --\n" +
+		"--  --\n" +
+		"-- a.txt\n" +
+		" -- a.txt --\n",
+	))
+
+	chk.Int(len(archive.files), 1)
+	chk.Str(
+		string(archive.files[0].data),
+		"--  --\n-- a.txt\n -- a.txt --\n",
+	)
+}